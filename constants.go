@@ -1,69 +1,55 @@
 package main
 
-import "time"
+import (
+	"time"
+
+	"github.com/mgomes/go-tetris/engine"
+)
 
 // Window configuration
 const (
-	windowWidth  = 600
+	windowWidth  = 720
 	windowHeight = 800
 	windowTitle  = "Go Tetris"
 )
 
-// Board dimensions
-const (
-	boardWidth  = 10
-	boardHeight = 20
-)
-
-// Rendering constants
+// Rendering constants. Boards are drawn with the largest cell size up to
+// maxCellSize that fits the board area; the vanish zone is not drawn.
 const (
-	cellSize     = 30
-	boardOffsetX = 50
-	boardOffsetY = 50
-	depthOffset  = 4
+	maxCellSize         = 30
+	maxBoardPixelWidth  = 360
+	maxBoardPixelHeight = 660
+	boardOffsetX        = 50
+	boardOffsetY        = 50
+	depthOffset         = 4
+	garbageMeterWidth   = 8  // Rising garbage meter left of the board
+	garbageMeterGap     = 10 // Space between the meter and the board border
 )
 
 // UI layout constants
 const (
-	holdBoxX      = boardOffsetX + boardWidth*cellSize + 50
-	holdBoxY      = boardOffsetY + 50
-	nextBoxX      = boardOffsetX + boardWidth*cellSize + 50
-	nextBoxY      = boardOffsetY + 200
-	scoreBoxX     = boardOffsetX + boardWidth*cellSize + 50
-	scoreBoxY     = boardOffsetY + 360  // Moved down slightly
-	levelBoxX     = boardOffsetX + boardWidth*cellSize + 50
-	levelBoxY     = boardOffsetY + 470  // Moved down more to create space
-	infoBoxWidth  = 100
-	infoBoxHeight = 60
-	miniBlockSize = 20
+	// Columns beside the board come from the layout for the board size
+	holdBoxY       = boardOffsetY + 50
+	nextBoxY       = holdBoxY
+	nextSlotSize   = 3 * miniBlockSize  // Height of each piece in the queue
+	statBoxY       = boardOffsetY + 180 // First of the mode's info boxes
+	statBoxSpacing = 95                 // Distance between info boxes, fitting six
+	infoBoxWidth   = 130
+	infoBoxHeight  = 60
+	miniBlockSize  = 20
+	
+	// Puzzle browser list
+	browserTop       = 140
+	browserLeft      = 80
+	browserRows      = 12
+	browserRowHeight = 48
 )
 
 // Game timing constants
 const (
-	frameTargetTime        = 16 * time.Millisecond
-	keyJustPressedWindow   = 50 * time.Millisecond
-	keyRepeatDelay         = 400 * time.Millisecond
-	keyRepeatInterval      = 200 * time.Millisecond
-	keyRepeatFastInterval  = 50 * time.Millisecond
-	keyFastThreshold       = 700 * time.Millisecond
-)
-
-// Scoring constants
-const (
-	linesPerLevel = 10
-	
-	// Normal line clear scores
-	scoreSingle = 100
-	scoreDouble = 300
-	scoreTriple = 500
-	scoreTetris = 800
-	
-	// Perfect clear scores
-	scorePerfectSingle       = 800
-	scorePerfectDouble       = 1200
-	scorePerfectTriple       = 1800
-	scorePerfectTetris       = 2000
-	scorePerfectTetrisB2B    = 3200
+	frameTargetTime = 16 * time.Millisecond
+	maxFrameLag     = 250 * time.Millisecond     // Simulation time dropped after a stall
+	announceFrames  = 2 * engine.FramesPerSecond // How long a clear stays announced
 )
 
 // Rendering style constants
@@ -77,10 +63,11 @@ const (
 	gridVerticalSpacing   = 30
 	
 	// Text rendering
-	digitWidth    = 15
-	digitHeight   = 20
-	letterSpacing = 35
-	letterSize    = 25
+	digitWidth       = 15
+	punctuationWidth = 8
+	digitHeight      = 20
+	letterSpacing    = 35
+	letterSize       = 25
 )
 
 // Color intensity multipliers
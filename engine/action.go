@@ -0,0 +1,15 @@
+package engine
+
+// Action is a single player command applied to a Game.
+type Action int
+
+const (
+	ActionMoveLeft Action = iota
+	ActionMoveRight
+	ActionSoftDrop
+	ActionHardDrop
+	ActionRotateCW
+	ActionRotateCCW
+	ActionHold
+	ActionPause
+)
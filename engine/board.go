@@ -0,0 +1,283 @@
+package engine
+
+import "math/bits"
+
+// Board dimensions. The board stores a vanish zone of BufferHeight hidden
+// rows above the visible rows of the playfield; row 0 is the top of the
+// vanish zone and the visible playfield starts at row BufferHeight.
+const (
+	DefaultWidth  = 10
+	DefaultHeight = 20
+	BufferHeight  = 20
+	MinWidth      = 4  // Narrowest board an I piece can rotate in
+	MaxWidth      = 32 // Widest board a row bitmask can hold
+	MinHeight     = 4  // Shortest board an upright I piece fits in
+	MaxHeight     = 60 // Tallest board that still draws at a readable size
+)
+
+// garbageColor is used for rows pushed up from below the board
+var garbageColor = [3]float32{0.4, 0.4, 0.5}
+
+// Board is the playfield. Each row is a bitmask with bit x set when column x
+// is filled, so collision and line checks are a few word operations.
+type Board struct {
+	Width  int            // Columns
+	Height int            // Visible rows below the vanish zone
+	Colors [][][3]float32 // Color of each filled cell
+	rows   []uint32       // Row bitmasks from the top of the vanish zone down
+	full   uint32         // Bitmask of a complete row
+}
+
+// NewBoard creates an empty board with the given number of columns and
+// visible rows, plus the vanish zone above them.
+func NewBoard(width, height int) *Board {
+	b := &Board{
+		Width:  width,
+		Height: height,
+		Colors: make([][][3]float32, height+BufferHeight),
+		rows:   make([]uint32, height+BufferHeight),
+		full:   uint32(1<<width - 1),
+	}
+	for y := range b.Colors {
+		b.Colors[y] = make([][3]float32, width)
+	}
+	return b
+}
+
+// Rows returns the number of rows stored, including the vanish zone.
+func (b *Board) Rows() int {
+	return len(b.rows)
+}
+
+// Clone returns a deep copy of the board.
+func (b *Board) Clone() *Board {
+	clone := NewBoard(b.Width, b.Height)
+	copy(clone.rows, b.rows)
+	for y := range b.Colors {
+		copy(clone.Colors[y], b.Colors[y])
+	}
+	return clone
+}
+
+// Spawn returns a new piece at the spawn position: centred (rounding left)
+// with its lowest blocks in the row just above the visible playfield, moved
+// by the piece's spawn offset.
+func (b *Board) Spawn(pieceType PieceType) *Piece {
+	piece := NewPiece(pieceType)
+	spawn := definition(pieceType).spawn
+	piece.X = (b.Width-piece.Size())/2 + spawn.X
+	piece.Y = BufferHeight + spawn.Y
+	return piece
+}
+
+// Filled reports whether the cell at column x, row y is filled. Cells
+// outside the board are empty.
+func (b *Board) Filled(x, y int) bool {
+	if x < 0 || x >= b.Width || y < 0 || y >= len(b.rows) {
+		return false
+	}
+	return b.rows[y]&(1<<x) != 0
+}
+
+// Fill fills the cell at column x, row y with the given color.
+func (b *Board) Fill(x, y int, color [3]float32) {
+	if x < 0 || x >= b.Width || y < 0 || y >= len(b.rows) {
+		return
+	}
+	b.rows[y] |= 1 << x
+	b.Colors[y][x] = color
+}
+
+func (b *Board) IsValidPosition(piece *Piece) bool {
+	return b.fits(piece.mask(), piece.X, piece.Y)
+}
+
+// fits reports whether a piece mask placed with its box at column x, row y
+// stays on the board without overlapping the stack.
+func (b *Board) fits(mask *pieceMask, x, y int) bool {
+	for i, m := range mask.rows {
+		if m == 0 {
+			continue
+		}
+
+		row := y + i
+		if row < 0 || row >= len(b.rows) {
+			return false
+		}
+
+		shifted, ok := b.shift(m, x)
+		if !ok || shifted&b.rows[row] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// shift moves a row of a piece mask to column x, reporting false if any of
+// its cells would leave the board.
+func (b *Board) shift(m uint32, x int) (uint32, bool) {
+	if x < 0 {
+		if bits.TrailingZeros32(m) < -x {
+			return 0, false
+		}
+		return m >> -x, true
+	}
+
+	shifted := uint64(m) << x
+	if shifted&^uint64(b.full) != 0 {
+		return 0, false
+	}
+	return uint32(shifted), true
+}
+
+// DropDistance returns how many rows the piece can fall before it lands.
+// It does not move the piece, so it suits ghost pieces and placement
+// searches.
+func (b *Board) DropDistance(piece *Piece) int {
+	mask := piece.mask()
+	distance := 0
+	for b.fits(mask, piece.X, piece.Y+distance+1) {
+		distance++
+	}
+	return distance
+}
+
+func (b *Board) PlacePiece(piece *Piece) {
+	color := piece.Color()
+	for _, cell := range piece.Cells() {
+		b.Fill(piece.X+cell.X, piece.Y+cell.Y, color)
+	}
+}
+
+// IsAboveVisible reports whether every block of the piece is in the vanish
+// zone above the visible playfield.
+func (b *Board) IsAboveVisible(piece *Piece) bool {
+	mask := piece.mask()
+	for i, m := range mask.rows {
+		if m != 0 && piece.Y+i >= BufferHeight {
+			return false
+		}
+	}
+	return true
+}
+
+// InsertGarbage pushes the stack up and fills the bottom of the board with
+// one garbage row per hole, each row full apart from the given hole column.
+// It reports false if blocks were pushed out of the top of the vanish zone.
+func (b *Board) InsertGarbage(holes ...int) bool {
+	fits := true
+	for _, hole := range holes {
+		fits = b.InsertGarbageRow(hole) && fits
+	}
+	return fits
+}
+
+// InsertGarbageRow pushes the stack up by one row and fills the bottom row
+// with garbage apart from the given hole columns. It reports false if
+// blocks were pushed out of the top of the vanish zone.
+func (b *Board) InsertGarbageRow(holes ...int) bool {
+	fits := b.rows[0] == 0
+
+	// The top row drops off and its colors are reused for the garbage
+	bottom := len(b.rows) - 1
+	colors := b.Colors[0]
+	copy(b.rows, b.rows[1:])
+	copy(b.Colors, b.Colors[1:])
+	b.Colors[bottom] = colors
+
+	b.rows[bottom] = b.full
+	for _, hole := range holes {
+		if hole >= 0 && hole < b.Width {
+			b.rows[bottom] &^= 1 << hole
+		}
+	}
+	for x := range b.Width {
+		b.Colors[bottom][x] = [3]float32{}
+		if b.Filled(x, bottom) {
+			b.Colors[bottom][x] = garbageColor
+		}
+	}
+	return fits
+}
+
+func (b *Board) ClearLines() int {
+	linesCleared := 0
+
+	for y := len(b.rows) - 1; y >= 0; y-- {
+		if b.isLineFull(y) {
+			b.removeLine(y)
+			linesCleared++
+			y++
+		}
+	}
+
+	return linesCleared
+}
+
+// FullRows returns the complete rows from the top of the board down. They
+// stay on the board until RemoveRows collapses the stack onto them.
+func (b *Board) FullRows() []int {
+	var rows []int
+	for y := range b.rows {
+		if b.isLineFull(y) {
+			rows = append(rows, y)
+		}
+	}
+	return rows
+}
+
+// RemoveRows removes the given rows, listed from the top down, and moves
+// the rows above them down.
+func (b *Board) RemoveRows(rows []int) {
+	// Removing a row only moves the rows above it, so the lower indices
+	// still hold
+	for _, y := range rows {
+		if y >= 0 && y < len(b.rows) {
+			b.removeLine(y)
+		}
+	}
+}
+
+func (b *Board) IsPerfectClear() bool {
+	for _, row := range b.rows {
+		if row != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// clearsToEmpty reports whether removing the full rows would leave the board
+// empty, so a perfect clear can be scored before the rows are removed.
+func (b *Board) clearsToEmpty() bool {
+	for _, row := range b.rows {
+		if row != 0 && row != b.full {
+			return false
+		}
+	}
+	return true
+}
+
+// isOccupied reports whether a cell is filled, treating everything outside
+// the board as filled.
+func (b *Board) isOccupied(x, y int) bool {
+	if x < 0 || x >= b.Width || y < 0 || y >= len(b.rows) {
+		return true
+	}
+	return b.rows[y]&(1<<x) != 0
+}
+
+func (b *Board) isLineFull(y int) bool {
+	return b.rows[y] == b.full
+}
+
+func (b *Board) removeLine(line int) {
+	// The cleared row's colors are emptied and reused for the new top row
+	colors := b.Colors[line]
+	copy(b.rows[1:line+1], b.rows[:line])
+	copy(b.Colors[1:line+1], b.Colors[:line])
+
+	b.rows[0] = 0
+	clear(colors)
+	b.Colors[0] = colors
+}
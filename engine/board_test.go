@@ -0,0 +1,72 @@
+package engine
+
+import "testing"
+
+// BenchmarkPlacements finds the landing row of every piece in every
+// orientation and column, as a bot searching for moves would.
+func BenchmarkPlacements(b *testing.B) {
+	board := boardFromRows("##..######", "###.######", "####.#####", "#########.")
+
+	var pieces []*Piece
+	for _, pt := range Tetrominoes.Pieces() {
+		piece := board.Spawn(pt)
+		for range 4 {
+			pieces = append(pieces, piece.Clone())
+			piece.Rotate(true)
+		}
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, piece := range pieces {
+			for x := -2; x < board.Width; x++ {
+				piece.X, piece.Y = x, 0
+				if board.IsValidPosition(piece) {
+					board.DropDistance(piece)
+				}
+			}
+		}
+	}
+}
+
+func TestBoardEdges(t *testing.T) {
+	board := NewBoard(MaxWidth, DefaultHeight)
+	piece := board.Spawn(PieceI)
+	piece.Y = board.Rows() - 2
+
+	for x, want := range map[int]bool{-1: false, 0: true, MaxWidth - 4: true, MaxWidth - 3: false} {
+		piece.X = x
+		if got := board.IsValidPosition(piece); got != want {
+			t.Errorf("I at column %d valid = %v, want %v", x, got, want)
+		}
+	}
+
+	piece.X = MaxWidth - 4
+	piece.Y += board.DropDistance(piece)
+	board.PlacePiece(piece)
+	if !board.Filled(MaxWidth-1, board.Rows()-1) || board.Filled(MaxWidth-5, board.Rows()-1) {
+		t.Error("I piece placed in the wrong cells at the right wall")
+	}
+}
+
+func TestInsertGarbageRow(t *testing.T) {
+	b := NewBoard(DefaultWidth, DefaultHeight)
+	b.Fill(4, 1, garbageColor)
+
+	if !b.InsertGarbageRow(2, 7) {
+		t.Fatal("garbage row reported a top out with an empty top row")
+	}
+	bottom := b.Rows() - 1
+	for x := range b.Width {
+		if b.Filled(x, bottom) == (x == 2 || x == 7) {
+			t.Errorf("bottom row cell %d filled %v", x, b.Filled(x, bottom))
+		}
+	}
+	if !b.Filled(4, 0) {
+		t.Error("stack was not pushed up")
+	}
+
+	if b.InsertGarbageRow(0) {
+		t.Error("pushing a block out of the vanish zone did not report a top out")
+	}
+}
@@ -0,0 +1,145 @@
+package engine
+
+import "time"
+
+// Clock measures elapsed game time. The game only reads it to report how
+// long it has been running; the simulation itself is driven by Step.
+type Clock interface {
+	Now() time.Duration
+}
+
+// Pauser is implemented by clocks that can stop while the game is paused
+type Pauser interface {
+	Pause()
+	Resume()
+}
+
+// RealClock measures wall time since it was created
+type RealClock struct {
+	start time.Time
+}
+
+func NewRealClock() *RealClock {
+	return &RealClock{start: time.Now()}
+}
+
+func (c *RealClock) Now() time.Duration {
+	return time.Since(c.start)
+}
+
+// PausableClock wraps another clock and stops counting while paused, so
+// time spent on the pause screen never counts as game time.
+type PausableClock struct {
+	base     Clock
+	paused   bool
+	pausedAt time.Duration // Base time when the clock was paused
+	offset   time.Duration // Total base time spent paused
+}
+
+func NewPausableClock(base Clock) *PausableClock {
+	return &PausableClock{base: base}
+}
+
+func (c *PausableClock) Now() time.Duration {
+	if c.paused {
+		return c.pausedAt - c.offset
+	}
+	return c.base.Now() - c.offset
+}
+
+func (c *PausableClock) Pause() {
+	if c.paused {
+		return
+	}
+	c.paused = true
+	c.pausedAt = c.base.Now()
+}
+
+func (c *PausableClock) Resume() {
+	if !c.paused {
+		return
+	}
+	c.paused = false
+	c.offset += c.base.Now() - c.pausedAt
+}
+
+// ManualClock only moves when advanced, for tests and replays
+type ManualClock struct {
+	now time.Duration
+}
+
+func NewManualClock() *ManualClock {
+	return &ManualClock{}
+}
+
+func (c *ManualClock) Now() time.Duration {
+	return c.now
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.now += d
+}
+
+// Elapsed returns the game time since the game started, stopping once the
+// game is over. It is zero until any countdown is over. While the game runs
+// it follows the clock; the final time is counted in simulated frames, so
+// the same inputs always give the same result whatever the clock did.
+func (g *Game) Elapsed() time.Duration {
+	if !g.Phase.Started() {
+		return 0
+	}
+	if g.GameOver {
+		return time.Duration(g.endFrame-g.startFrame) * FrameDuration
+	}
+	return g.clock.Now() - g.startedAt
+}
+
+// setPaused pauses or resumes the game and its clock
+func (g *Game) setPaused(paused bool) {
+	g.Paused = paused
+	if pauser, ok := g.clock.(Pauser); ok {
+		if paused {
+			pauser.Pause()
+		} else {
+			pauser.Resume()
+		}
+	}
+	g.publish(Paused{Paused: paused})
+}
+
+// endGame stops the game and its timer, whether the player topped out or
+// reached the mode's goal
+func (g *Game) endGame(reason GameOverReason) {
+	g.GameOver = true
+	g.GameOverWhy = reason
+	g.endFrame = g.Frame
+	if reason.ToppedOut() {
+		g.publish(TopOut{Reason: reason})
+	} else {
+		g.publish(Finished{Reason: reason})
+	}
+}
+
+// Remaining returns the game time left before the time limit, or zero
+// without one. The limit itself is counted in frames, so the clock only
+// decides what is shown.
+func (g *Game) Remaining() time.Duration {
+	if g.Config.TimeLimit <= 0 || g.GameOverWhy == GameOverTimeUp {
+		return 0
+	}
+	return max(g.Config.TimeLimit-g.Elapsed(), 0)
+}
+
+// durationFrames returns the whole frames it takes d to pass
+func durationFrames(d time.Duration) int {
+	return int((d*FramesPerSecond + time.Second - 1) / time.Second)
+}
+
+// PiecesPerSecond returns the pieces locked per second of game time
+func (g *Game) PiecesPerSecond() float64 {
+	seconds := g.Elapsed().Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(g.Pieces) / seconds
+}
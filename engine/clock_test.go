@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPausableClockSkipsPausedTime(t *testing.T) {
+	base := NewManualClock()
+	clock := NewPausableClock(base)
+
+	base.Advance(2 * time.Second)
+	clock.Pause()
+	base.Advance(5 * time.Second)
+	if got := clock.Now(); got != 2*time.Second {
+		t.Fatalf("paused clock reads %v, want 2s", got)
+	}
+
+	clock.Resume()
+	base.Advance(time.Second)
+	if got := clock.Now(); got != 3*time.Second {
+		t.Fatalf("resumed clock reads %v, want 3s", got)
+	}
+}
+
+func TestGameTimerIgnoresPause(t *testing.T) {
+	base := NewManualClock()
+	config := DefaultConfig()
+	config.Clock = NewPausableClock(base)
+	g := NewGame(config)
+
+	base.Advance(time.Second)
+	g.Apply(ActionPause)
+	base.Advance(time.Minute)
+	g.Apply(ActionPause)
+	base.Advance(time.Second)
+
+	if got := g.Elapsed(); got != 2*time.Second {
+		t.Errorf("Elapsed() = %v, want 2s", got)
+	}
+}
+
+func TestDefaultTimerCountsFrames(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	stepFrames(g, FramesPerSecond)
+
+	if got := g.Elapsed(); got != FramesPerSecond*FrameDuration {
+		t.Errorf("Elapsed() = %v after one second of frames", got)
+	}
+}
@@ -0,0 +1,79 @@
+package engine
+
+import "time"
+
+// Preview queue length: the default, and the most a game can show
+const (
+	defaultPreviews = 5
+	MaxPreviews     = 7
+)
+
+// Config holds the rules a game is played with. Each game mode supplies its
+// own Config to NewGame.
+type Config struct {
+	Seed       int64          // Seed for every random choice the game makes
+	Clock      Clock          // Game timer; nil counts simulated frames
+	Randomizer RandomizerKind // Piece generator
+	PieceSet   *PieceSet      // Pieces dealt; nil deals the Tetrominoes
+	Scoring    ScoringKind    // Scoring rules
+	Previews   int            // Upcoming pieces shown, 0 to MaxPreviews
+	Width      int            // Board columns, MinWidth to MaxWidth
+	Height     int            // Visible board rows, MinHeight to MaxHeight
+
+	LockDelay     int             // Frames a grounded piece can move before it locks
+	LockReset     LockResetPolicy // What restarts the lock delay
+	MaxLockResets int             // Limit on move resets per piece for LockResetMove
+
+	// Frames the cleared rows stay on the board, and frames between a lock
+	// and the next spawn (ARE). DefaultConfig uses neither; modes and
+	// rulesets set their own.
+	LineClearDelay int
+	SpawnDelay     int
+
+	Countdown    int           // Frames counted down before the game starts
+	StartOnInput bool          // The game and its timer start with the first input
+	LineGoal     int           // Lines that finish the game; 0 plays until top out
+	TimeLimit    time.Duration // Game time that ends the game; 0 for no limit
+	FixedLevel   bool          // The level stays at StartLevel however many lines are cleared
+	StartLevel   int           // Level the game starts on; 0 starts on the first level
+	LevelGoal    LevelGoal     // Lines each level takes
+	FinalLevel   int           // Completing this level finishes the game; 0 plays on
+
+	Garbage          int         // Garbage rows filling the bottom of the board at the start, fewer than Height
+	GarbageKind      GarbageKind // How garbage rows are generated
+	GarbageMessiness float64     // Chance a GarbageMessy hole moves, 0 to 1
+	GarbageGoal      bool        // Clearing every garbage row finishes the game
+	RisingGarbage    bool        // Garbage rows rise on a timer that speeds up with the level
+
+	Puzzle      *Puzzle    // Position, queue and objective to play; nil deals from the randomizer
+	NoHold      bool       // The hold box cannot be used
+	NoGhost     bool       // Clients should not show where the piece would land
+	NoKicks     bool       // Rotations only turn in place, never kicking off walls or the stack
+	NoHardDrop  bool       // Hard drop is not available
+	NoSpawnDrop bool       // New pieces stay where they spawn instead of dropping a row at once
+	Speed       SpeedCurve // Gravity table the levels follow
+
+	DAS             int     // Frames a direction is held before it auto-repeats
+	ARR             int     // Frames between auto-repeat moves; 0 moves to the wall
+	SoftDropFactor  float64 // Gravity multiplier while soft drop is held
+	SoftDropGravity float64 // G while soft drop is held if faster than gravity; overrides SoftDropFactor
+}
+
+// DefaultConfig returns the guideline rules with a zero seed. Callers that
+// want a different game every time should set Seed themselves.
+func DefaultConfig() Config {
+	return Config{
+		Randomizer:    RandomizerBag7,
+		Scoring:       ScoringGuideline,
+		Previews:      defaultPreviews,
+		Width:         DefaultWidth,
+		Height:        DefaultHeight,
+		LockDelay:     defaultLockDelay,
+		LockReset:     LockResetMove,
+		MaxLockResets: defaultMaxLockResets,
+
+		DAS:            defaultDAS,
+		ARR:            defaultARR,
+		SoftDropFactor: defaultSoftDropFactor,
+	}
+}
@@ -0,0 +1,51 @@
+package engine
+
+import "testing"
+
+// filledCells counts the blocks on the board
+func filledCells(b *Board) int {
+	n := 0
+	for y := range b.Rows() {
+		for x := range b.Width {
+			if b.Filled(x, y) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// TestHeadlessGame plays a piece without a window: the engine must be
+// usable from plain Go code such as bots and tests.
+func TestHeadlessGame(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	before := g.Snapshot()
+	if before.CurrentPiece == nil || len(before.Queue) == 0 || before.GameOver {
+		t.Fatal("new game has no piece to play")
+	}
+	spawnY := before.CurrentPiece.Y
+
+	if !g.Apply(ActionHardDrop) {
+		t.Fatal("hard drop was not applied")
+	}
+	after := g.Snapshot()
+	if n := filledCells(&after.Board); n != 4 {
+		t.Errorf("%d cells filled after the first piece locked, want 4", n)
+	}
+	if after.CurrentPiece.Y > spawnY+1 {
+		t.Errorf("next piece at row %d, want it at the top", after.CurrentPiece.Y)
+	}
+
+	// A snapshot keeps the state it was taken in
+	if n := filledCells(&before.Board); n != 0 || before.CurrentPiece.Y != spawnY {
+		t.Errorf("earlier snapshot changed: %d cells filled, piece at row %d", n, before.CurrentPiece.Y)
+	}
+
+	g.Apply(ActionPause)
+	if g.Apply(ActionMoveLeft) || g.Apply(ActionHardDrop) {
+		t.Error("paused game took input")
+	}
+	if !g.Snapshot().Paused {
+		t.Error("snapshot of a paused game is not paused")
+	}
+}
@@ -0,0 +1,128 @@
+package engine
+
+// Event is something that happened in a game. Subscribers switch on the
+// concrete type:
+//
+//	game.Subscribe(func(e engine.Event) {
+//		switch e := e.(type) {
+//		case engine.LinesCleared:
+//			fmt.Println(e.Result)
+//		case engine.TopOut:
+//			fmt.Println("game over:", e.Reason)
+//		}
+//	})
+type Event interface {
+	event()
+}
+
+// PieceSpawned is sent when a new piece enters the board, before its first
+// drop into view.
+type PieceSpawned struct {
+	Piece Piece
+}
+
+// PieceMoved is sent for every successful move, including gravity, soft
+// drops and the fall of a hard drop.
+type PieceMoved struct {
+	Piece  Piece // The piece after the move
+	DX, DY int
+}
+
+// Rotated is sent for every successful rotation.
+type Rotated struct {
+	Piece     Piece // The piece after the rotation
+	Clockwise bool
+}
+
+// Kicked follows Rotated when the rotation only fitted after moving the
+// piece by a wall kick.
+type Kicked struct {
+	Piece  Piece
+	Kick   int   // Index of the kick test used, from 1
+	Offset Point // How far the kick moved the piece
+}
+
+// Held is sent when the current piece goes into the hold box.
+type Held struct {
+	Piece   PieceType // The piece put on hold
+	Swapped bool      // The held piece came back out in its place
+}
+
+// Locked is sent for every piece that locks onto the stack.
+type Locked struct {
+	Piece  Piece
+	Result LockResult
+}
+
+// LinesCleared follows Locked when the piece completed rows. The result
+// carries the spin, back-to-back and combo information.
+type LinesCleared struct {
+	Rows   []int // Board rows cleared, top first
+	Result LockResult
+}
+
+// LevelUp is sent when a line clear raises the level.
+type LevelUp struct {
+	Level int
+}
+
+// TopOut is sent when the game is lost.
+type TopOut struct {
+	Reason GameOverReason
+}
+
+// Finished is sent when the game ends because the mode's goal was reached
+// or its time ran out, or a puzzle was solved or failed.
+type Finished struct {
+	Reason GameOverReason
+}
+
+// Paused is sent when the game is paused or resumed.
+type Paused struct {
+	Paused bool // False when the game resumes
+}
+
+func (PieceSpawned) event() {}
+func (PieceMoved) event()   {}
+func (Rotated) event()      {}
+func (Kicked) event()       {}
+func (Held) event()         {}
+func (Locked) event()       {}
+func (LinesCleared) event() {}
+func (LevelUp) event()      {}
+func (TopOut) event()       {}
+func (Finished) event()     {}
+func (Paused) event()       {}
+
+// subscriber is a registered event handler
+type subscriber struct {
+	id int
+	fn func(Event)
+}
+
+// Subscribe calls fn with every event the game publishes from now on and
+// returns a function that stops the calls. Events are delivered
+// synchronously from Step, Apply and the other Game methods, in the order
+// they happen.
+func (g *Game) Subscribe(fn func(Event)) (unsubscribe func()) {
+	g.lastSubscriber++
+	id := g.lastSubscriber
+	g.subscribers = append(g.subscribers, subscriber{id: id, fn: fn})
+
+	return func() {
+		for i, s := range g.subscribers {
+			if s.id == id {
+				// Copy so a publish in progress keeps its own list
+				g.subscribers = append(g.subscribers[:i:i], g.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publish sends an event to every subscriber
+func (g *Game) publish(e Event) {
+	for _, s := range g.subscribers {
+		s.fn(e)
+	}
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// recordEvents subscribes to g and returns the names of the events it
+// publishes, with the events themselves.
+func recordEvents(g *Game) (names *[]string, events *[]Event) {
+	names, events = new([]string), new([]Event)
+	g.Subscribe(func(e Event) {
+		*names = append(*names, fmt.Sprintf("%T", e)[len("engine."):])
+		*events = append(*events, e)
+	})
+	return names, events
+}
+
+func TestEventStream(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	g.Board = boardFromRows("#.........", "#########.", "#########.", "#########.", "#########.")
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X, g.CurrentPiece.Y = 7, BufferHeight
+	g.Lines = linesPerLevel - 1
+	names, events := recordEvents(g)
+
+	g.Apply(ActionHardDrop)
+
+	want := []string{"PieceMoved", "Locked", "LinesCleared", "LevelUp", "PieceSpawned", "PieceMoved"}
+	if !reflect.DeepEqual(*names, want) {
+		t.Fatalf("events %v, want %v", *names, want)
+	}
+	cleared := (*events)[2].(LinesCleared)
+	if !reflect.DeepEqual(cleared.Rows, []int{boardRows - 4, boardRows - 3, boardRows - 2, boardRows - 1}) ||
+		cleared.Result.Lines != 4 || !cleared.Result.Difficult {
+		t.Errorf("LinesCleared %+v, want the bottom four rows as a tetris", cleared)
+	}
+	if up := (*events)[3].(LevelUp); up.Level != 2 {
+		t.Errorf("LevelUp to level %d, want 2", up.Level)
+	}
+
+	// A T against the left wall has to kick right to turn flat
+	*names, *events = nil, nil
+	g.CurrentPiece = g.Board.Spawn(PieceT)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X, g.CurrentPiece.Y = -1, BufferHeight
+	g.Apply(ActionRotateCW)
+	want = []string{"Rotated", "Kicked"}
+	if !reflect.DeepEqual(*names, want) {
+		t.Fatalf("events %v, want %v", *names, want)
+	}
+	if kicked := (*events)[1].(Kicked); kicked.Kick != 1 || kicked.Offset != (Point{X: 1}) {
+		t.Errorf("Kicked %+v, want the first kick one column right", kicked)
+	}
+
+	*names = nil
+	g.Apply(ActionHold)
+	g.Apply(ActionPause)
+	want = []string{"Held", "PieceSpawned", "PieceMoved", "Paused"}
+	if !reflect.DeepEqual(*names, want) {
+		t.Fatalf("events %v, want %v", *names, want)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	count := 0
+	unsubscribe := g.Subscribe(func(Event) { count++ })
+	others, _ := recordEvents(g)
+
+	g.Apply(ActionMoveLeft)
+	unsubscribe()
+	g.Apply(ActionMoveRight)
+
+	if count != 1 || len(*others) != 2 {
+		t.Errorf("unsubscribed handler saw %d events and the other %d, want 1 and 2", count, len(*others))
+	}
+}
@@ -0,0 +1,493 @@
+// Package engine implements the rules of the game: the board, the pieces,
+// gravity, scoring and levelling. It has no graphics or windowing
+// dependencies so it can be driven by the desktop client, bots or tests.
+package engine
+
+import (
+	"math/rand"
+	"slices"
+	"time"
+)
+
+type Game struct {
+	Config         Config
+	Board          *Board
+	CurrentPiece   *Piece
+	Queue          []*Piece // Upcoming pieces shown in the preview, next first
+	HeldPiece      *Piece
+	CanHold        bool
+	Score          int
+	Lines          int
+	Level          int
+	Pieces         int // Pieces locked
+	Tetrises       int // Four-line clears
+	TSpins         int // T-spins, including minis and those that cleared nothing
+	AwardedLines   int // Line values awarded towards the variable level goal
+	GarbageLeft    int // Garbage rows still on the board
+	GarbageTimer   int // Frames until the next rising garbage row
+	GameOver       bool
+	GameOverWhy    GameOverReason
+	Paused         bool
+	Phase          Phase         // Where the current piece is in its life cycle
+	PhaseTimer     int           // Frames left in a line clear or spawn delay
+	ClearingRows   []int         // Rows being cleared during PhaseLineClear, top first
+	Frame          int           // Frames simulated so far
+	Gravity        float64       // Rows the piece falls per frame (G)
+	LockTimer      int           // Frames the current piece has spent grounded
+	LockResets     int           // Lock delay resets used by the current piece
+	Combo          int           // Line-clearing locks in a row after the first, -1 without a chain
+	BackToBack     int           // Difficult clears in a row after the first, -1 without a chain
+	LastLock       LockResult    // What happened when the last piece locked
+	subscribers    []subscriber  // Event handlers registered with Subscribe
+	lastSubscriber int           // ID of the most recent subscription
+	rng            *rand.Rand    // Random number generator
+	randomizer     Randomizer    // Piece generator drawing from rng
+	scorer         Scorer        // Scoring rules
+	lowestY        int           // Lowest row reached by the current piece
+	gravityAcc     float64       // Fractional rows of gravity carried between frames
+	input          heldInput     // Held movement keys and auto-shift state
+	clock          Clock         // Source of elapsed game time
+	frameClock     *ManualClock  // Clock advanced by Step when none is configured
+	startedAt      time.Duration // Clock time when the game started
+	startFrame     int           // Frame the game started on
+	endFrame       int           // Frame the game ended on
+
+	lastMoveRotation bool // The last successful move was a rotation
+	lastKick         int  // Index of the kick test that rotation used
+	softDropped      int  // Rows the current piece has been soft dropped
+	hardDropped      int  // Rows the current piece fell in its hard drop
+	completed        bool // The final level has been completed
+	garbageHole      int  // Hole column of the last GarbageMessy row, -1 before the first
+	gravityBlocked   bool // Gravity tried to move the piece this frame and could not
+}
+
+func NewGame(config Config) *Game {
+	// Every random choice comes from the configured seed so that the same
+	// seed and inputs always replay the same game
+	source := rand.NewSource(config.Seed)
+	config.Previews = min(max(config.Previews, 0), MaxPreviews)
+	if config.Width <= 0 {
+		config.Width = DefaultWidth
+	}
+	if config.Height <= 0 {
+		config.Height = DefaultHeight
+	}
+	if config.PieceSet == nil {
+		config.PieceSet = Tetrominoes
+	}
+	if config.Puzzle != nil {
+		config.PieceSet = Tetrominoes
+		config.Width = config.Puzzle.Width()
+		config.Height = max(config.Height, len(config.Puzzle.Board))
+		config.Previews = len(config.Puzzle.Queue) - 1
+		config.NoHold = config.NoHold || !config.Puzzle.Hold
+	}
+	config.Width = min(max(config.Width, MinWidth, config.PieceSet.maxSize()), MaxWidth)
+	config.Height = min(max(config.Height, MinHeight, config.PieceSet.maxSize()), MaxHeight)
+	config.Garbage = min(config.Garbage, config.Height-1) // Leave a row to play in
+	config.StartLevel = max(config.StartLevel, config.LevelGoal.firstLevel())
+
+	g := &Game{
+		Config:      config,
+		Board:       NewBoard(config.Width, config.Height),
+		Score:       0,
+		Lines:       0,
+		Level:       config.StartLevel,
+		GameOver:    false,
+		Paused:      false,
+		CanHold:     true,
+		Combo:       -1,
+		BackToBack:  -1,
+		rng:         rand.New(source),
+		garbageHole: -1,
+	}
+	g.randomizer = NewRandomizer(config.Randomizer, g.rng, g.Config.PieceSet.Pieces())
+	g.scorer = NewScorer(config.Scoring)
+
+	// Without a clock the timer counts simulated frames, which keeps it
+	// deterministic for bots and replays
+	g.clock = config.Clock
+	if g.clock == nil {
+		g.frameClock = NewManualClock()
+		g.clock = g.frameClock
+	}
+
+	g.updateGravity() // Set initial speed based on the start level
+	g.AddGarbage(config.Garbage)
+	if p := config.Puzzle; p != nil {
+		// Puzzles deal their own queue, all of it in the preview
+		p.fill(g.Board)
+		for _, t := range p.Queue {
+			g.Queue = append(g.Queue, g.Board.Spawn(t))
+		}
+	} else {
+		for range g.Config.Previews {
+			g.Queue = append(g.Queue, g.randomPiece())
+		}
+	}
+
+	// The first piece is dealt when the game starts, after any countdown
+	switch {
+	case config.Countdown > 0:
+		g.Phase = PhaseCountdown
+		g.PhaseTimer = config.Countdown
+	case config.StartOnInput:
+		g.Phase = PhaseReady
+	default:
+		g.begin()
+	}
+
+	return g
+}
+
+func (g *Game) randomPiece() *Piece {
+	return g.Board.Spawn(g.randomizer.Next())
+}
+
+// nextPiece takes the piece at the front of the preview queue and tops the
+// queue up from the randomizer. Without a preview the randomizer deals
+// directly. A puzzle's queue is fixed and only shrinks.
+func (g *Game) nextPiece() *Piece {
+	if g.Config.Puzzle != nil {
+		return g.nextPuzzlePiece()
+	}
+	if len(g.Queue) == 0 {
+		return g.randomPiece()
+	}
+	next := g.Queue[0]
+	g.Queue = append(g.Queue[1:], g.randomPiece())
+	return next
+}
+
+// Step advances the simulation by exactly one frame (1/60 s), applying
+// auto-shift, gravity and the lock delay, or counting down a line clear or
+// spawn delay. The game never reads the wall
+// clock, so the same sequence of Step, Apply, Press and Release calls always
+// produces the same game.
+func (g *Game) Step() {
+	if g.GameOver || g.Paused {
+		return
+	}
+
+	g.Frame++
+	if g.frameClock != nil {
+		g.frameClock.Advance(FrameDuration)
+	}
+	if g.Config.TimeLimit > 0 && g.Phase.Started() && g.Frame-g.startFrame >= durationFrames(g.Config.TimeLimit) {
+		g.endGame(GameOverTimeUp)
+		return
+	}
+	if g.Config.RisingGarbage && g.Phase.Started() {
+		g.updateRisingGarbage()
+		if g.GameOver {
+			return
+		}
+	}
+	if !g.Phase.Active() {
+		g.updateDelay()
+		return
+	}
+	g.updateAutoShift()
+	g.applyGravity()
+	g.updateLockDelay()
+}
+
+// applyGravity moves the piece down by the current G, carrying fractional
+// rows over to later frames. Gravity above 1G drops several rows per frame.
+func (g *Game) applyGravity() {
+	g.gravityAcc += g.currentGravity()
+	g.gravityBlocked = false
+	for g.gravityAcc >= 1 {
+		g.gravityAcc--
+		if !g.MovePiece(0, 1) {
+			g.gravityAcc = 0
+			g.gravityBlocked = true
+			return
+		}
+		if g.input.softDrop {
+			g.softDropped++
+		}
+	}
+}
+
+// Apply performs a single player action and reports whether it changed the
+// game state.
+func (g *Game) Apply(action Action) bool {
+	if action == ActionPause {
+		if g.GameOver {
+			return false
+		}
+		g.setPaused(!g.Paused)
+		return true
+	}
+
+	if g.GameOver || g.Paused {
+		return false
+	}
+	if g.Phase == PhaseReady {
+		g.begin()
+	}
+
+	// Nothing is in play during a countdown, line clear or spawn delay
+	if !g.Phase.Active() {
+		return false
+	}
+
+	switch action {
+	case ActionMoveLeft:
+		return g.MovePiece(-1, 0)
+	case ActionMoveRight:
+		return g.MovePiece(1, 0)
+	case ActionSoftDrop:
+		if !g.MovePiece(0, 1) {
+			return false
+		}
+		g.softDropped++
+		return true
+	case ActionHardDrop:
+		if g.Config.NoHardDrop {
+			return false
+		}
+		g.HardDrop()
+		return true
+	case ActionRotateCW:
+		return g.RotatePiece(true)
+	case ActionRotateCCW:
+		return g.RotatePiece(false)
+	case ActionHold:
+		return g.HoldPiece()
+	}
+	return false
+}
+
+func (g *Game) MovePiece(dx, dy int) bool {
+	g.CurrentPiece.X += dx
+	g.CurrentPiece.Y += dy
+
+	if !g.Board.IsValidPosition(g.CurrentPiece) {
+		g.CurrentPiece.X -= dx
+		g.CurrentPiece.Y -= dy
+		return false
+	}
+
+	if dy > 0 {
+		g.pieceDescended()
+	}
+	if dx != 0 {
+		g.pieceShifted()
+	}
+	g.lastMoveRotation = false
+
+	// Moves are the hottest path, so skip building the event when nobody
+	// is listening
+	if len(g.subscribers) > 0 {
+		g.publish(PieceMoved{Piece: *g.CurrentPiece, DX: dx, DY: dy})
+	}
+	return true
+}
+
+func (g *Game) RotatePiece(clockwise bool) bool {
+	original := *g.CurrentPiece
+	rotated := original
+	rotated.Rotate(clockwise)
+
+	// Try each SRS kick for this transition in order. Without kicks only
+	// the first test, turning in place, is tried.
+	kicks := wallKicks(original.Type, original.Rotation, clockwise)
+	if g.Config.NoKicks {
+		kicks = kicks[:1]
+	}
+	for i, kick := range kicks {
+		rotated.X = original.X + kick.X
+		rotated.Y = original.Y + kick.Y
+
+		if g.Board.IsValidPosition(&rotated) {
+			*g.CurrentPiece = rotated
+			g.pieceDescended()
+			g.pieceShifted()
+			g.lastMoveRotation = true
+			g.lastKick = i
+
+			if len(g.subscribers) > 0 {
+				g.publish(Rotated{Piece: rotated, Clockwise: clockwise})
+				if i > 0 {
+					g.publish(Kicked{Piece: rotated, Kick: i, Offset: kick})
+				}
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// HardDrop drops the piece as far as it goes and locks it at once,
+// skipping the lock delay.
+func (g *Game) HardDrop() {
+	rows := g.Board.DropDistance(g.CurrentPiece)
+	if rows > 0 {
+		g.MovePiece(0, rows)
+		g.hardDropped = rows
+	}
+	g.lockPiece()
+}
+
+func (g *Game) lockPiece() {
+	spin := g.detectTSpin()
+	lockedOut := g.Board.IsAboveVisible(g.CurrentPiece)
+	g.Board.PlacePiece(g.CurrentPiece)
+	if lockedOut {
+		g.endGame(GameOverLockOut)
+		return
+	}
+	g.Pieces++
+	if spin != SpinNone {
+		g.TSpins++
+	}
+
+	// The lock is scored straight away; the rows leave the board when the
+	// line clear delay is over
+	rows := g.Board.FullRows()
+	linesCleared := len(rows)
+	result := LockResult{
+		Piece:        g.CurrentPiece.Type,
+		Lines:        linesCleared,
+		Garbage:      g.garbageCleared(rows),
+		Spin:         spin,
+		PerfectClear: linesCleared > 0 && g.Board.clearsToEmpty(),
+		Difficult:    linesCleared == 4 || (linesCleared > 0 && spin != SpinNone),
+		Level:        g.Level,
+		SoftDropped:  g.softDropped,
+		HardDropped:  g.hardDropped,
+		Frame:        g.Frame,
+	}
+
+	// A lock that clears nothing breaks the combo but not the back-to-back
+	// chain, which only an easy line clear breaks
+	if linesCleared > 0 {
+		g.Combo++
+		if result.Difficult {
+			g.BackToBack++
+		} else {
+			g.BackToBack = -1
+		}
+	} else {
+		g.Combo = -1
+	}
+	result.Combo = g.Combo
+	result.BackToBack = result.Difficult && g.BackToBack > 0
+
+	result.Points = g.scorer.Score(result)
+	g.Score += result.Points
+	g.LastLock = result
+	g.publish(Locked{Piece: *g.CurrentPiece, Result: result})
+
+	if linesCleared > 0 {
+		g.Lines += linesCleared
+		if linesCleared == 4 {
+			g.Tetrises++
+		}
+		g.publish(LinesCleared{Rows: slices.Clone(rows), Result: result})
+	}
+	g.AwardedLines += awardedLines(result)
+	g.GarbageLeft -= result.Garbage
+	g.updateLevel()
+
+	if reason := g.outcome(); reason != GameOverNone {
+		g.Board.RemoveRows(rows)
+		g.endGame(reason)
+		return
+	}
+
+	g.CanHold = true
+	if linesCleared > 0 && g.Config.LineClearDelay > 0 {
+		g.Phase = PhaseLineClear
+		g.PhaseTimer = g.Config.LineClearDelay
+		g.ClearingRows = rows
+		return
+	}
+	g.Board.RemoveRows(rows)
+	g.startSpawnDelay()
+}
+
+// outcome reports how the last lock ended the game, or GameOverNone while
+// play goes on
+func (g *Game) outcome() GameOverReason {
+	switch {
+	case g.Config.Puzzle != nil:
+		return g.puzzleOutcome()
+	case g.goalReached():
+		return GameOverFinished
+	}
+	return GameOverNone
+}
+
+// goalReached reports whether the last lock met the mode's goal
+func (g *Game) goalReached() bool {
+	switch {
+	case g.completed:
+		return true
+	case g.Config.LineGoal > 0 && g.Lines >= g.Config.LineGoal:
+		return true
+	case g.Config.GarbageGoal && g.GarbageLeft == 0 && g.LastLock.Garbage > 0:
+		return true
+	}
+	return false
+}
+
+// spawnPiece makes piece the current piece at its spawn position. The game
+// ends with a block out if that position is occupied; otherwise the piece
+// drops one row straight away when there is room, as in the guideline,
+// unless the rules turn the spawn drop off. A puzzle with no piece left to
+// deal has failed.
+func (g *Game) spawnPiece(piece *Piece) {
+	if piece == nil {
+		g.endGame(GameOverFailed)
+		return
+	}
+	g.CurrentPiece = piece
+	g.Phase = PhaseFalling
+	g.PhaseTimer = 0
+	g.resetLockState()
+	g.lastMoveRotation = false
+	g.softDropped = 0
+	g.hardDropped = 0
+	g.gravityAcc = 0
+
+	if !g.Board.IsValidPosition(piece) {
+		g.endGame(GameOverBlockOut)
+		return
+	}
+	g.publish(PieceSpawned{Piece: *piece})
+	if !g.Config.NoSpawnDrop {
+		g.MovePiece(0, 1)
+	}
+}
+
+func (g *Game) HoldPiece() bool {
+	if !g.CanHold || g.Config.NoHold {
+		return false
+	}
+
+	// Both pieces go back to their spawn position and orientation. The
+	// last piece of a puzzle has nothing to swap with.
+	held := g.HeldPiece
+	if held == nil && g.Config.Puzzle != nil && len(g.Queue) == 0 {
+		return false
+	}
+	g.HeldPiece = g.Board.Spawn(g.CurrentPiece.Type)
+	g.CanHold = false
+
+	g.publish(Held{Piece: g.HeldPiece.Type, Swapped: held != nil})
+
+	if held == nil {
+		g.spawnPiece(g.nextPiece())
+	} else {
+		g.spawnPiece(g.Board.Spawn(held.Type))
+	}
+	return true
+}
+
+func (g *Game) updateGravity() {
+	g.Gravity = g.Config.Speed.Gravity(g.Level)
+}
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// playScript drives a game with a fixed sequence of actions and frames.
+func playScript(g *Game) []PieceType {
+	script := []Action{
+		ActionMoveLeft, ActionRotateCW, ActionHardDrop,
+		ActionHold, ActionMoveRight, ActionMoveRight, ActionHardDrop,
+		ActionRotateCCW, ActionSoftDrop, ActionHardDrop,
+		ActionHold, ActionMoveLeft, ActionMoveLeft, ActionMoveLeft, ActionHardDrop,
+	}
+
+	var pieces []PieceType
+	for i := range 200 {
+		pieces = append(pieces, g.CurrentPiece.Type)
+		g.Apply(script[i%len(script)])
+		stepFrames(g, 6)
+	}
+	return pieces
+}
+
+func TestSameSeedReplaysSameGame(t *testing.T) {
+	config := DefaultConfig()
+	config.Seed = 12345
+
+	first := NewGame(config)
+	second := NewGame(config)
+
+	firstPieces := playScript(first)
+	secondPieces := playScript(second)
+
+	if !reflect.DeepEqual(firstPieces, secondPieces) {
+		t.Errorf("piece sequences differ:\n%v\n%v", firstPieces, secondPieces)
+	}
+	if !reflect.DeepEqual(first.Snapshot(), second.Snapshot()) {
+		t.Error("snapshots differ after identical input")
+	}
+}
+
+func TestDifferentSeedsDealDifferentPieces(t *testing.T) {
+	a := DefaultConfig()
+	a.Seed = 1
+	b := DefaultConfig()
+	b.Seed = 2
+
+	if reflect.DeepEqual(playScript(NewGame(a)), playScript(NewGame(b))) {
+		t.Error("different seeds dealt identical piece sequences")
+	}
+}
+
+func TestGravityPerFrame(t *testing.T) {
+	tests := []struct {
+		level  int
+		frames int
+		rows   int
+	}{
+		{1, 59, 0}, // 0.01667G needs a full second per row
+		{1, 60, 1},
+		{13, 2, 1}, // 0.92G
+		{14, 1, 1}, // 1.46G carries the fraction over
+		{14, 2, 2},
+		{20, 1, DefaultHeight}, // 36.6G reaches the floor in a single frame
+	}
+
+	for _, tt := range tests {
+		g := NewGame(DefaultConfig())
+		g.Level = tt.level
+		g.updateGravity()
+		g.CurrentPiece = g.Board.Spawn(PieceT)
+		startY := g.CurrentPiece.Y
+
+		stepFrames(g, tt.frames)
+		if rows := g.CurrentPiece.Y - startY; rows != tt.rows {
+			t.Errorf("level %d after %d frames fell %d rows, want %d", tt.level, tt.frames, rows, tt.rows)
+		}
+	}
+}
+
+func TestAutoShift(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	g.CurrentPiece = g.Board.Spawn(PieceT)
+	startX := g.CurrentPiece.X
+
+	g.Press(ActionMoveLeft)
+	if g.CurrentPiece.X != startX-1 {
+		t.Fatalf("press moved piece to %d, want %d", g.CurrentPiece.X, startX-1)
+	}
+
+	stepFrames(g, defaultDAS-1)
+	if g.CurrentPiece.X != startX-1 {
+		t.Fatalf("piece auto-shifted before DAS charged")
+	}
+
+	stepFrames(g, 1)
+	if g.CurrentPiece.X != startX-2 {
+		t.Fatalf("piece did not auto-shift once DAS charged")
+	}
+
+	g.Release(ActionMoveLeft)
+	stepFrames(g, 20)
+	if g.CurrentPiece.X != startX-2 {
+		t.Errorf("piece kept moving after release")
+	}
+}
+
+func TestTopOutReasons(t *testing.T) {
+	t.Run("block out", func(t *testing.T) {
+		g := NewGame(DefaultConfig())
+		for y := BufferHeight - 4; y < boardRows; y++ {
+			for x := 3; x < 7; x++ {
+				g.Board.Fill(x, y, garbageColor)
+			}
+		}
+		g.lockPiece()
+		if g.GameOverWhy != GameOverBlockOut {
+			t.Errorf("GameOverWhy = %v, want block out", g.GameOverWhy)
+		}
+	})
+
+	t.Run("lock out", func(t *testing.T) {
+		g := NewGame(DefaultConfig())
+		g.CurrentPiece = g.Board.Spawn(PieceO)
+		g.CurrentPiece.X, g.CurrentPiece.Y = 0, BufferHeight-2
+		g.lockPiece()
+		if g.GameOverWhy != GameOverLockOut {
+			t.Errorf("GameOverWhy = %v, want lock out", g.GameOverWhy)
+		}
+	})
+
+	t.Run("garbage top out", func(t *testing.T) {
+		g := NewGame(DefaultConfig())
+		g.Board.Fill(0, 0, garbageColor)
+		g.InsertGarbage(9)
+		if g.GameOverWhy != GameOverTopOut {
+			t.Errorf("GameOverWhy = %v, want top out", g.GameOverWhy)
+		}
+	})
+
+	t.Run("garbage pushes piece up", func(t *testing.T) {
+		g := NewGame(DefaultConfig())
+		g.CurrentPiece = g.Board.Spawn(PieceO)
+		g.CurrentPiece.Y = boardRows - 2
+		g.InsertGarbage(0, 0)
+		if g.GameOver || g.CurrentPiece.Y != boardRows-4 {
+			t.Errorf("piece at row %d, game over %v; want row %d", g.CurrentPiece.Y, g.GameOver, boardRows-4)
+		}
+	})
+}
+
+func TestPreviewQueue(t *testing.T) {
+	for _, previews := range []int{0, 1, 5, MaxPreviews, 12} {
+		config := DefaultConfig()
+		config.Seed = 7
+		config.Previews = previews
+		g := NewGame(config)
+
+		want := min(previews, MaxPreviews)
+		if len(g.Queue) != want {
+			t.Fatalf("%d previews: queue holds %d pieces, want %d", previews, len(g.Queue), want)
+		}
+
+		// Every queue length deals the same pieces in the same order
+		reference := config
+		reference.Previews = 0
+		r := NewGame(reference)
+		for i := range 20 {
+			got, want := g.nextPiece().Type, r.nextPiece().Type
+			if got != want {
+				t.Fatalf("%d previews: piece %d is %v, want %v", previews, i, got, want)
+			}
+		}
+	}
+}
+
+func TestBoardDimensions(t *testing.T) {
+	tests := []struct {
+		width, height int
+		spawnX        int
+	}{
+		{4, 20, 0},
+		{12, 24, 4},
+		{10, 40, 3},
+	}
+
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.Width, config.Height = tt.width, tt.height
+		g := NewGame(config)
+
+		if g.Board.Width != tt.width || g.Board.Rows() != tt.height+BufferHeight {
+			t.Errorf("%dx%d: board is %dx%d", tt.width, tt.height, g.Board.Width, g.Board.Rows())
+		}
+		if piece := g.Board.Spawn(PieceT); piece.X != tt.spawnX {
+			t.Errorf("%dx%d: T spawns in column %d, want %d", tt.width, tt.height, piece.X, tt.spawnX)
+		}
+
+		// A full bottom row clears at any width
+		g.CurrentPiece = g.Board.Spawn(PieceI)
+		g.CurrentPiece.X = 0
+		for x := 4; x < tt.width; x++ {
+			g.Board.Fill(x, g.Board.Rows()-1, garbageColor)
+		}
+		g.HardDrop()
+		if g.Lines != 1 {
+			t.Errorf("%dx%d: cleared %d lines, want 1", tt.width, tt.height, g.Lines)
+		}
+	}
+}
+
+func TestBoardLimits(t *testing.T) {
+	tests := []struct {
+		width, height int
+		wantW, wantH  int
+	}{
+		{0, 0, DefaultWidth, DefaultHeight},
+		{1, 1, MinWidth, MinHeight},
+		{100, 1000, MaxWidth, MaxHeight},
+	}
+
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.Width, config.Height = tt.width, tt.height
+		g := NewGame(config)
+		if g.Board.Width != tt.wantW || g.Board.Height != tt.wantH {
+			t.Errorf("%dx%d: board is %dx%d, want %dx%d", tt.width, tt.height, g.Board.Width, g.Board.Height, tt.wantW, tt.wantH)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package engine
+
+import "math"
+
+// GarbageKind selects how garbage rows are generated
+type GarbageKind int
+
+const (
+	GarbageCheese GarbageKind = iota // One hole per row in a random column
+	GarbageMessy                     // One hole per row that moves with probability GarbageMessiness
+	GarbageRandom                    // Random empty cells in every row
+)
+
+// randomGarbageHoleChance is the chance each cell of a GarbageRandom row
+// is empty
+const randomGarbageHoleChance = 0.3
+
+// Rising garbage timing: a row every 8 seconds on level 1, but never more
+// than one a second
+const (
+	riseFramesLevel1 = 8 * FramesPerSecond
+	minRiseFrames    = FramesPerSecond
+)
+
+// RiseInterval returns the frames between rising garbage rows on the
+// current level. It follows the gravity curve, shrinking with the square
+// root of the level's gravity so it speeds up more gently than the pieces.
+func (g *Game) RiseInterval() int {
+	scale := math.Sqrt(GravityForLevel(1) / g.Gravity)
+	return max(int(riseFramesLevel1*scale), minRiseFrames)
+}
+
+// updateRisingGarbage counts down to the next rising garbage row and
+// pushes it up when the time comes.
+func (g *Game) updateRisingGarbage() {
+	g.GarbageTimer--
+	if g.GarbageTimer > 0 {
+		return
+	}
+	g.GarbageTimer = g.RiseInterval()
+	g.AddGarbage(1)
+}
+
+// InsertGarbage pushes garbage rows up from the bottom of the board, one per
+// hole column. The current piece is pushed up with the stack if the two
+// would overlap. The game ends with a top out if anything is pushed out of
+// the top of the vanish zone.
+func (g *Game) InsertGarbage(holes ...int) {
+	if g.GameOver {
+		return
+	}
+	g.garbageInserted(len(holes), g.Board.InsertGarbage(holes...))
+}
+
+// AddGarbage pushes n rows of the configured kind of garbage up from the
+// bottom of the board, like InsertGarbage.
+func (g *Game) AddGarbage(n int) {
+	if g.GameOver {
+		return
+	}
+
+	fits := true
+	for range n {
+		fits = g.Board.InsertGarbageRow(g.garbageHoles()...) && fits
+	}
+	g.garbageInserted(n, fits)
+}
+
+// garbageHoles returns the empty columns of the next garbage row
+func (g *Game) garbageHoles() []int {
+	width := g.Board.Width
+	switch g.Config.GarbageKind {
+	case GarbageMessy:
+		// The hole lines up with the row above unless it moves elsewhere
+		if g.garbageHole < 0 {
+			g.garbageHole = g.rng.Intn(width)
+		} else if g.rng.Float64() < g.Config.GarbageMessiness {
+			g.garbageHole = (g.garbageHole + 1 + g.rng.Intn(width-1)) % width
+		}
+		return []int{g.garbageHole}
+	case GarbageRandom:
+		var holes []int
+		for x := range width {
+			if g.rng.Float64() < randomGarbageHoleChance {
+				holes = append(holes, x)
+			}
+		}
+		if len(holes) == 0 {
+			holes = append(holes, g.rng.Intn(width))
+		}
+		return holes
+	default:
+		return []int{g.rng.Intn(width)}
+	}
+}
+
+// garbageInserted follows rows of garbage pushed onto the board: it ends the
+// game if the stack was pushed out of the top, and otherwise moves the
+// current piece and any rows being cleared up with the stack.
+func (g *Game) garbageInserted(rows int, fits bool) {
+	if !fits {
+		g.endGame(GameOverTopOut)
+		return
+	}
+	g.GarbageLeft += rows
+
+	// Rows waiting to be cleared move up with the stack
+	for i := range g.ClearingRows {
+		g.ClearingRows[i] -= rows
+	}
+	if g.CurrentPiece == nil || !g.Phase.Active() {
+		return
+	}
+
+	for !g.Board.IsValidPosition(g.CurrentPiece) {
+		if g.CurrentPiece.Y < 0 {
+			g.endGame(GameOverTopOut)
+			return
+		}
+		g.CurrentPiece.Y--
+	}
+	g.lowestY = min(g.lowestY, g.CurrentPiece.Y)
+}
+
+// garbageCleared returns how many of the full rows are garbage. Garbage is
+// only ever pushed in from below, so the garbage rows are always the
+// bottom GarbageLeft rows of the board.
+func (g *Game) garbageCleared(rows []int) int {
+	cleared := 0
+	for _, y := range rows {
+		if y >= g.Board.Rows()-g.GarbageLeft {
+			cleared++
+		}
+	}
+	return cleared
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestGarbageKinds(t *testing.T) {
+	const rows = 8
+	tests := []struct {
+		kind      GarbageKind
+		messiness float64
+	}{
+		{GarbageCheese, 0},
+		{GarbageMessy, 0},
+		{GarbageMessy, 1},
+		{GarbageRandom, 0},
+	}
+
+	for _, tt := range tests {
+		g := NewGame(DigConfig(rows, tt.kind, tt.messiness))
+		if g.GarbageLeft != rows {
+			t.Errorf("kind %d: %d garbage rows, want %d", tt.kind, g.GarbageLeft, rows)
+		}
+
+		b := g.Board
+		if b.rows[b.Rows()-rows-1] != 0 {
+			t.Errorf("kind %d: garbage above the bottom %d rows", tt.kind, rows)
+		}
+		for y := b.Rows() - rows; y < b.Rows(); y++ {
+			holes := bits.OnesCount32(b.full &^ b.rows[y])
+			above := b.rows[y-1]
+			switch {
+			case holes == 0:
+				t.Errorf("kind %d: garbage row %d has no hole", tt.kind, y)
+			case tt.kind != GarbageRandom && holes != 1:
+				t.Errorf("kind %d: garbage row %d has %d holes, want 1", tt.kind, y, holes)
+			case tt.kind == GarbageMessy && tt.messiness == 0 && y > b.Rows()-rows && b.rows[y] != above:
+				t.Errorf("garbage row %d hole moved with no messiness", y)
+			case tt.kind == GarbageMessy && tt.messiness == 1 && y > b.Rows()-rows && b.rows[y] == above:
+				t.Errorf("garbage row %d hole stayed put with full messiness", y)
+			}
+		}
+	}
+}
+
+func TestDigGarbageLeavesARow(t *testing.T) {
+	config := DigConfig(DefaultHeight, GarbageCheese, 0)
+	g := NewGame(config)
+	if g.GameOver || g.GarbageLeft != DefaultHeight-1 {
+		t.Errorf("game over %v with %d garbage rows, want play with %d", g.GameOver, g.GarbageLeft, DefaultHeight-1)
+	}
+}
+
+func TestDigFinishes(t *testing.T) {
+	g := NewGame(DigConfig(2, GarbageMessy, 0))
+	hole := bits.TrailingZeros32(g.Board.full &^ g.Board.rows[boardRows-1])
+
+	// An I dropped down the lined-up holes clears both garbage rows
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X, g.CurrentPiece.Y = hole-2, BufferHeight
+	g.HardDrop()
+
+	if g.LastLock.Garbage != 2 || g.GarbageLeft != 0 {
+		t.Errorf("cleared %d garbage rows leaving %d, want 2 leaving none", g.LastLock.Garbage, g.GarbageLeft)
+	}
+	if !g.GameOver || g.GameOverWhy != GameOverFinished {
+		t.Errorf("game over %v (%v), want finished", g.GameOver, g.GameOverWhy)
+	}
+}
+
+func TestRisingGarbage(t *testing.T) {
+	g := NewGame(SurvivalConfig())
+	stepFrames(g, countdownFrames)
+	if g.GarbageTimer != riseFramesLevel1 {
+		t.Fatalf("first garbage row due in %d frames, want %d", g.GarbageTimer, riseFramesLevel1)
+	}
+
+	stepFrames(g, riseFramesLevel1-1)
+	if g.GarbageLeft != 0 {
+		t.Fatal("garbage rose early")
+	}
+	stepFrames(g, 1)
+	if g.GarbageLeft != 1 || g.GarbageTimer != riseFramesLevel1 {
+		t.Errorf("%d garbage rows with the next due in %d frames, want 1 and %d", g.GarbageLeft, g.GarbageTimer, riseFramesLevel1)
+	}
+
+	// Garbage rises faster as the level goes up, but at most once a second
+	previous := g.RiseInterval()
+	for g.Level = 2; g.Level <= 20; g.Level++ {
+		interval := g.RiseInterval()
+		if interval > previous || interval < minRiseFrames {
+			t.Errorf("level %d garbage every %d frames, after %d on the level before", g.Level, interval, previous)
+		}
+		previous = interval
+	}
+}
@@ -0,0 +1,103 @@
+package engine
+
+// Auto-shift and soft drop defaults, in frames at 60 Hz
+const (
+	defaultDAS            = 10
+	defaultARR            = 2
+	defaultSoftDropFactor = 20
+)
+
+// heldInput tracks the movement keys the player is holding down
+type heldInput struct {
+	left, right bool
+	softDrop    bool
+	direction   int // -1 left, 1 right, 0 none; the most recently pressed wins
+	shiftFrames int // Frames the current direction has been held
+}
+
+// Press starts holding an action. Sideways movement repeats by itself once
+// it has been held for DAS frames, and soft drop speeds up gravity until it
+// is released. Every other action happens once, exactly as with Apply.
+func (g *Game) Press(action Action) bool {
+	switch action {
+	case ActionMoveLeft:
+		g.input.left = true
+		g.startShift(-1)
+	case ActionMoveRight:
+		g.input.right = true
+		g.startShift(1)
+	case ActionSoftDrop:
+		g.input.softDrop = true
+		g.gravityAcc = 0
+	}
+	return g.Apply(action)
+}
+
+// Release stops holding an action started with Press.
+func (g *Game) Release(action Action) {
+	switch action {
+	case ActionMoveLeft:
+		g.input.left = false
+		if g.input.direction == -1 {
+			g.stopShift()
+		}
+	case ActionMoveRight:
+		g.input.right = false
+		if g.input.direction == 1 {
+			g.stopShift()
+		}
+	case ActionSoftDrop:
+		g.input.softDrop = false
+	}
+}
+
+func (g *Game) startShift(direction int) {
+	g.input.direction = direction
+	g.input.shiftFrames = 0
+}
+
+// stopShift hands auto-shift back to the other direction if it is still held
+func (g *Game) stopShift() {
+	switch {
+	case g.input.left:
+		g.startShift(-1)
+	case g.input.right:
+		g.startShift(1)
+	default:
+		g.startShift(0)
+	}
+}
+
+// updateAutoShift repeats the held sideways movement once DAS has charged.
+func (g *Game) updateAutoShift() {
+	if g.input.direction == 0 {
+		return
+	}
+
+	g.input.shiftFrames++
+	repeat := g.input.shiftFrames - g.Config.DAS
+	if repeat < 0 {
+		return
+	}
+
+	if g.Config.ARR <= 0 {
+		for g.MovePiece(g.input.direction, 0) {
+		}
+		return
+	}
+
+	if repeat%g.Config.ARR == 0 {
+		g.MovePiece(g.input.direction, 0)
+	}
+}
+
+// currentGravity returns the G applied this frame, including soft drop.
+func (g *Game) currentGravity() float64 {
+	if g.input.softDrop && g.Config.SoftDropGravity > 0 {
+		return max(g.Gravity, g.Config.SoftDropGravity)
+	}
+	if g.input.softDrop && g.Config.SoftDropFactor > 1 {
+		return g.Gravity * g.Config.SoftDropFactor
+	}
+	return g.Gravity
+}
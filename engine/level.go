@@ -0,0 +1,88 @@
+package engine
+
+// LevelGoal decides how many lines each level takes
+type LevelGoal int
+
+const (
+	LevelGoalFixed    LevelGoal = iota // A level every 10 lines
+	LevelGoalVariable                  // Guideline variable goal: 5 x level awarded lines per level
+	LevelGoalNES                       // NES: levels from 0, the first after min(10s+10, max(100, 10s-50)) lines from level s, then every 10
+)
+
+// firstLevel returns the lowest level a game can start on
+func (goal LevelGoal) firstLevel() int {
+	if goal == LevelGoalNES {
+		return 0
+	}
+	return 1
+}
+
+// linesPerLevel is the fixed goal
+const linesPerLevel = 10
+
+// variableGoalStep is the growth of the variable goal: level n takes 5n lines
+const variableGoalStep = 5
+
+// awardedLines returns the line value a lock is worth under the variable
+// goal: its guideline score over 100 (a Tetris counts 8, a T-spin double
+// 12), with half again for a back-to-back clear.
+func awardedLines(result LockResult) int {
+	lines := lineClearScore(result.Lines, result.Spin, false, false) / 100
+	if result.BackToBack {
+		lines = lines * 3 / 2
+	}
+	return lines
+}
+
+// goalLines returns the lines counted towards the level goal
+func (g *Game) goalLines() int {
+	if g.Config.LevelGoal == LevelGoalVariable {
+		return g.AwardedLines
+	}
+	return g.Lines
+}
+
+// linesForLevel returns the goal lines needed to reach a level from the
+// start level
+func (g *Game) linesForLevel(level int) int {
+	start := g.Config.StartLevel
+	switch g.Config.LevelGoal {
+	case LevelGoalVariable:
+		return variableGoalStep * (triangle(level-1) - triangle(start-1))
+	case LevelGoalNES:
+		if level <= start {
+			return 0
+		}
+		first := min(start*linesPerLevel+linesPerLevel, max(100, start*linesPerLevel-50))
+		return first + (level-start-1)*linesPerLevel
+	}
+	return (level - start) * linesPerLevel
+}
+
+// triangle returns 1 + 2 + ... + n
+func triangle(n int) int {
+	return n * (n + 1) / 2
+}
+
+// LinesToNextLevel returns the goal lines still needed for the next level
+func (g *Game) LinesToNextLevel() int {
+	return max(g.linesForLevel(g.Level+1)-g.goalLines(), 0)
+}
+
+// updateLevel raises the level as far as the goal lines reach. Completing
+// the final level finishes the mode instead.
+func (g *Game) updateLevel() {
+	for g.goalLines() >= g.linesForLevel(g.Level+1) {
+		if g.Config.FinalLevel > 0 && g.Level >= g.Config.FinalLevel {
+			g.completed = true
+			return
+		}
+		if g.Config.FixedLevel {
+			return
+		}
+
+		g.Level++
+		g.updateGravity()
+		g.publish(LevelUp{Level: g.Level})
+	}
+}
@@ -0,0 +1,84 @@
+package engine
+
+// LockResetPolicy decides which piece movements restart the lock delay
+type LockResetPolicy int
+
+const (
+	LockResetMove LockResetPolicy = iota // Guideline: any move or rotation, up to MaxLockResets
+	LockResetStep                        // Only falling to a new lowest row
+	LockResetNone                        // Never; the delay runs whenever the piece is grounded
+	LockOnGravity                        // NES: no delay; the piece locks when gravity next pulls it against the stack
+)
+
+// Lock delay defaults from the guideline
+const (
+	defaultLockDelay     = 30 // frames, 500 ms
+	defaultMaxLockResets = 15
+)
+
+// onGround reports whether the current piece is resting on the stack or the
+// floor and so is counting down its lock delay.
+func (g *Game) onGround() bool {
+	g.CurrentPiece.Y++
+	grounded := !g.Board.IsValidPosition(g.CurrentPiece)
+	g.CurrentPiece.Y--
+	return grounded
+}
+
+// updateLockDelay runs the lock delay for one frame and locks the piece
+// once it has run out. It reports whether the piece is grounded.
+func (g *Game) updateLockDelay() bool {
+	if !g.onGround() {
+		g.Phase = PhaseFalling
+		return false
+	}
+
+	g.Phase = PhaseLocking
+	if g.Config.LockReset == LockOnGravity {
+		if g.gravityBlocked {
+			g.lockPiece()
+		}
+		return true
+	}
+	g.LockTimer++
+	outOfResets := g.Config.LockReset == LockResetMove && g.LockResets >= g.Config.MaxLockResets
+	if g.LockTimer >= g.Config.LockDelay || outOfResets {
+		g.lockPiece()
+	}
+	return true
+}
+
+// pieceShifted restarts the lock delay after a successful move or rotation,
+// if the reset policy allows it.
+func (g *Game) pieceShifted() {
+	if g.Config.LockReset != LockResetMove || g.LockResets >= g.Config.MaxLockResets {
+		return
+	}
+
+	// Only movement made while the lock delay is running counts as a reset
+	if g.LockTimer > 0 || g.onGround() {
+		g.LockTimer = 0
+		g.LockResets++
+	}
+}
+
+// pieceDescended restarts the lock delay and the reset budget when the piece
+// reaches a row lower than it has been before.
+func (g *Game) pieceDescended() {
+	if g.CurrentPiece.Y <= g.lowestY {
+		return
+	}
+
+	g.lowestY = g.CurrentPiece.Y
+	if g.Config.LockReset != LockResetNone {
+		g.LockTimer = 0
+		g.LockResets = 0
+	}
+}
+
+// resetLockState clears the lock delay for a newly spawned piece.
+func (g *Game) resetLockState() {
+	g.LockTimer = 0
+	g.LockResets = 0
+	g.lowestY = g.CurrentPiece.Y
+}
@@ -0,0 +1,118 @@
+package engine
+
+import "testing"
+
+// stepFrames advances the game by n frames.
+func stepFrames(g *Game, n int) {
+	for range n {
+		g.Step()
+	}
+}
+
+// groundedGame returns a game whose current T piece rests on the floor.
+func groundedGame(policy LockResetPolicy) *Game {
+	config := DefaultConfig()
+	config.LockReset = policy
+
+	g := NewGame(config)
+	g.CurrentPiece = g.Board.Spawn(PieceT)
+	g.CurrentPiece.X, g.CurrentPiece.Y = 3, boardRows-2
+	g.resetLockState()
+	return g
+}
+
+func TestLockDelayWaitsBeforeLocking(t *testing.T) {
+	g := groundedGame(LockResetMove)
+	piece := g.CurrentPiece
+
+	stepFrames(g, 24)
+	if g.CurrentPiece != piece {
+		t.Fatal("piece locked before the lock delay expired")
+	}
+
+	stepFrames(g, 6)
+	if g.CurrentPiece == piece {
+		t.Fatal("piece did not lock after the lock delay expired")
+	}
+}
+
+func TestLockResetPolicies(t *testing.T) {
+	tests := []struct {
+		policy LockResetPolicy
+		moves  int  // sideways moves, each followed by 24 frames on the ground
+		locked bool // whether the piece has locked afterwards
+	}{
+		{LockResetMove, 5, false},
+		{LockResetMove, 16, true},
+		{LockResetStep, 2, true},
+		{LockResetNone, 2, true},
+	}
+
+	for _, tt := range tests {
+		g := groundedGame(tt.policy)
+		piece := g.CurrentPiece
+
+		for i := range tt.moves {
+			if g.CurrentPiece != piece {
+				break
+			}
+			if i%2 == 0 {
+				g.MovePiece(1, 0)
+			} else {
+				g.MovePiece(-1, 0)
+			}
+			stepFrames(g, 24)
+		}
+
+		if locked := g.CurrentPiece != piece; locked != tt.locked {
+			t.Errorf("policy %d after %d moves: locked = %v, want %v", tt.policy, tt.moves, locked, tt.locked)
+		}
+	}
+}
+
+func TestHardDropSkipsLockDelay(t *testing.T) {
+	g := groundedGame(LockResetMove)
+	piece := g.CurrentPiece
+
+	g.HardDrop()
+	if g.CurrentPiece == piece {
+		t.Fatal("hard drop did not lock the piece")
+	}
+}
+
+func TestLineClearAndSpawnDelay(t *testing.T) {
+	config := DefaultConfig()
+	config.LineClearDelay = 20
+	config.SpawnDelay = 10
+
+	g := NewGame(config)
+	g.Board = boardFromRows("#.........", "#########.", "#########.")
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X = 7
+	next := g.Queue[0]
+
+	g.HardDrop()
+	if g.Phase != PhaseLineClear || g.Lines != 2 || len(g.Board.FullRows()) != 2 {
+		t.Fatalf("after lock: phase %v, %d lines, %d full rows; want line clear with 2 rows left to remove",
+			g.Phase, g.Lines, len(g.Board.FullRows()))
+	}
+	if g.Apply(ActionMoveLeft) {
+		t.Error("piece moved during the line clear delay")
+	}
+
+	stepFrames(g, 20)
+	if g.Phase != PhaseSpawnDelay || len(g.Board.FullRows()) != 0 {
+		t.Fatalf("after line clear delay: phase %v, %d full rows; want spawn delay with rows removed",
+			g.Phase, len(g.Board.FullRows()))
+	}
+
+	stepFrames(g, 9)
+	if g.Phase != PhaseSpawnDelay {
+		t.Fatalf("phase %v one frame before the spawn, want spawn delay", g.Phase)
+	}
+	stepFrames(g, 1)
+	if !g.Phase.Active() || g.CurrentPiece != next {
+		t.Fatalf("phase %v after the spawn delay, want the next piece in play", g.Phase)
+	}
+}
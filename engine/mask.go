@@ -0,0 +1,21 @@
+package engine
+
+// pieceMask is one orientation of a piece as a bitmask per row of its
+// bounding box, top to bottom. Bit n is column n of the box, so shifting a
+// row left by the piece's X lines it up with the board's row bitmasks.
+type pieceMask struct {
+	rows [MaxPieceSize]uint32
+}
+
+func maskOf(cells []Point) pieceMask {
+	var mask pieceMask
+	for _, cell := range cells {
+		mask.rows[cell.Y] |= 1 << cell.X
+	}
+	return mask
+}
+
+// mask returns the precomputed mask for the piece's type and orientation.
+func (p Piece) mask() *pieceMask {
+	return &p.state().mask
+}
@@ -0,0 +1,89 @@
+package engine
+
+import "time"
+
+// countdownFrames is the "3, 2, 1" before a timed mode starts
+const countdownFrames = 3 * FramesPerSecond
+
+// lineClearFrames is how long cleared rows stay on the board in Marathon and
+// Survival. The modes against the clock and puzzles clear at once, so the
+// pause never costs time.
+const lineClearFrames = 20
+
+// SprintGoals are the usual Sprint line targets
+var SprintGoals = []int{20, 40, 100}
+
+// SprintConfig returns the rules for a Sprint: clear the given number of
+// lines as fast as possible. The timer starts with the first input after a
+// countdown, and gravity stays at level 1 throughout.
+func SprintConfig(lines int) Config {
+	config := DefaultConfig()
+	config.Countdown = countdownFrames
+	config.StartOnInput = true
+	config.LineGoal = lines
+	config.FixedLevel = true
+	return config
+}
+
+// DefaultUltraTime is the usual length of an Ultra game
+const DefaultUltraTime = 2 * time.Minute
+
+// UltraConfig returns the rules for Ultra: score as much as possible before
+// the time limit runs out. Gravity stays at level 1 throughout.
+func UltraConfig(limit time.Duration) Config {
+	config := DefaultConfig()
+	config.Countdown = countdownFrames
+	config.TimeLimit = limit
+	config.FixedLevel = true
+	return config
+}
+
+// MarathonGoals are the usual Marathon lengths in lines
+var MarathonGoals = []int{150, 200}
+
+// MarathonConfig returns the rules for a Marathon of the given number of
+// lines, which finishes when the last of its levels (one per 10 lines) is
+// complete. With zero lines the game is endless. Under the variable goal
+// the levels are the same but each takes 5 x level awarded lines.
+func MarathonConfig(lines int, goal LevelGoal) Config {
+	config := DefaultConfig()
+	config.LevelGoal = goal
+	config.FinalLevel = lines / linesPerLevel
+	config.LineClearDelay = lineClearFrames
+	return config
+}
+
+// DigConfig returns the rules for a Dig race: the board starts with the
+// given number of garbage rows, and the game finishes when the last of them
+// is cleared. The timer starts with the first input after a countdown.
+func DigConfig(rows int, kind GarbageKind, messiness float64) Config {
+	config := DefaultConfig()
+	config.Countdown = countdownFrames
+	config.StartOnInput = true
+	config.FixedLevel = true
+	config.Garbage = rows
+	config.GarbageKind = kind
+	config.GarbageMessiness = messiness
+	config.GarbageGoal = true
+	return config
+}
+
+// SurvivalConfig returns the rules for Survival: garbage rows rise from the
+// bottom, faster on every level, and the game lasts until the stack tops
+// out.
+func SurvivalConfig() Config {
+	config := DefaultConfig()
+	config.Countdown = countdownFrames
+	config.RisingGarbage = true
+	config.LineClearDelay = lineClearFrames
+	return config
+}
+
+// PuzzleConfig returns the rules for playing a puzzle: its board, queue and
+// objective, with gravity at level 1.
+func PuzzleConfig(p *Puzzle) Config {
+	config := DefaultConfig()
+	config.Puzzle = p
+	config.FixedLevel = true
+	return config
+}
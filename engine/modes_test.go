@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSprint(t *testing.T) {
+	g := NewGame(SprintConfig(2))
+	if g.Phase != PhaseCountdown || g.CurrentPiece != nil {
+		t.Fatalf("phase %v at the start, want a countdown with no piece dealt", g.Phase)
+	}
+	if g.Apply(ActionMoveLeft) {
+		t.Error("piece moved during the countdown")
+	}
+
+	stepFrames(g, countdownFrames+30)
+	if g.Phase != PhaseReady || g.Elapsed() != 0 {
+		t.Fatalf("phase %v with %v elapsed after the countdown, want ready with the timer stopped", g.Phase, g.Elapsed())
+	}
+
+	// The first input starts the game and is applied to the first piece
+	first := g.Queue[0].Type
+	if !g.Apply(ActionMoveLeft) || g.CurrentPiece.Type != first {
+		t.Fatal("first input did not start the game with the first queued piece")
+	}
+
+	g.Board = boardFromRows("#########.", "#########.")
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X = 7
+	stepFrames(g, 60)
+	g.Apply(ActionHardDrop)
+
+	if !g.GameOver || g.GameOverWhy != GameOverFinished || g.Pieces != 1 {
+		t.Fatalf("game over %v (%v) after %d pieces, want finished after 1", g.GameOver, g.GameOverWhy, g.Pieces)
+	}
+	if want := 60 * FrameDuration; g.Elapsed() != want {
+		t.Errorf("sprint took %v, want the %v since the first input", g.Elapsed(), want)
+	}
+	if pps := g.PiecesPerSecond(); pps < 0.99 || pps > 1.01 {
+		t.Errorf("%v pieces per second, want 1", pps)
+	}
+}
+
+func TestSprintTimeCountsFrames(t *testing.T) {
+	clock := NewManualClock()
+	config := SprintConfig(2)
+	config.Clock = clock
+	g := NewGame(config)
+	stepFrames(g, countdownFrames+30)
+	g.Apply(ActionMoveLeft)
+
+	// A stall moves the clock on without simulating the frames
+	clock.Advance(time.Minute)
+	stepFrames(g, 60)
+	g.Board = boardFromRows("#########.", "#########.")
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X = 7
+	g.Apply(ActionHardDrop)
+
+	if want := 60 * FrameDuration; !g.GameOver || g.Elapsed() != want {
+		t.Errorf("game over %v after %v, want the %v simulated", g.GameOver, g.Elapsed(), want)
+	}
+}
+
+func TestUltra(t *testing.T) {
+	g := NewGame(UltraConfig(10 * FrameDuration))
+	g.Board = boardFromRows("#.........", "#########.", "#########.", "#########.", "#########.")
+	stepFrames(g, countdownFrames)
+	if !g.Phase.Active() || g.Remaining() != 10*FrameDuration {
+		t.Fatalf("phase %v with %v left after the countdown, want play with the full time", g.Phase, g.Remaining())
+	}
+
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X = 7
+	g.Apply(ActionHardDrop)
+	if g.Tetrises != 1 || g.Level != 1 {
+		t.Errorf("%d tetrises on level %d, want 1 on level 1", g.Tetrises, g.Level)
+	}
+
+	stepFrames(g, 9)
+	if g.GameOver {
+		t.Fatal("game ended before the time limit")
+	}
+	stepFrames(g, 1)
+	if !g.GameOver || g.GameOverWhy != GameOverTimeUp || g.Remaining() != 0 {
+		t.Errorf("game over %v (%v) with %v left, want time up", g.GameOver, g.GameOverWhy, g.Remaining())
+	}
+
+	// The limit is counted in frames whatever the clock says
+	config := UltraConfig(10 * FrameDuration)
+	config.Clock = NewManualClock()
+	g = NewGame(config)
+	stepFrames(g, countdownFrames+9)
+	if g.GameOver {
+		t.Fatal("game ended before the time limit with a stopped clock")
+	}
+	stepFrames(g, 1)
+	if g.GameOverWhy != GameOverTimeUp {
+		t.Errorf("game over %q after the time limit with a stopped clock, want time up", g.GameOverWhy)
+	}
+}
+
+func TestMarathonGoals(t *testing.T) {
+	tests := []struct {
+		goal     LevelGoal
+		result   LockResult
+		awarded  int
+		level    int
+		nextGoal int
+	}{
+		{LevelGoalFixed, LockResult{Lines: 4}, 8, 1, 6},
+		{LevelGoalVariable, LockResult{Lines: 1}, 1, 1, 4},
+		{LevelGoalVariable, LockResult{Lines: 4}, 8, 2, 7},
+		{LevelGoalVariable, LockResult{Lines: 2, Spin: SpinFull, BackToBack: true}, 18, 3, 12},
+		{LevelGoalVariable, LockResult{Spin: SpinMini}, 1, 1, 4},
+	}
+
+	for _, tt := range tests {
+		g := NewGame(MarathonConfig(0, tt.goal))
+		g.Lines += tt.result.Lines
+		g.AwardedLines += awardedLines(tt.result)
+		g.updateLevel()
+
+		if g.AwardedLines != tt.awarded || g.Level != tt.level || g.LinesToNextLevel() != tt.nextGoal {
+			t.Errorf("%v under goal %d: awarded %d, level %d, %d to go; want %d, %d, %d",
+				tt.result, tt.goal, g.AwardedLines, g.Level, g.LinesToNextLevel(), tt.awarded, tt.level, tt.nextGoal)
+		}
+	}
+}
+
+func TestMarathonFinishes(t *testing.T) {
+	g := NewGame(MarathonConfig(20, LevelGoalFixed))
+	g.Lines = 18
+	g.updateLevel()
+	if g.Level != 2 {
+		t.Fatalf("level %d after 18 lines, want 2", g.Level)
+	}
+
+	g.Board = boardFromRows("#########.", "#########.")
+	g.CurrentPiece = g.Board.Spawn(PieceI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X = 7
+	g.HardDrop()
+	if !g.GameOver || g.GameOverWhy != GameOverFinished || g.Level != 2 {
+		t.Errorf("game over %v (%v) on level %d, want finished on level 2", g.GameOver, g.GameOverWhy, g.Level)
+	}
+}
+
+func TestModeLineClearDelays(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   int
+	}{
+		{"marathon", MarathonConfig(150, LevelGoalFixed), lineClearFrames},
+		{"survival", SurvivalConfig(), lineClearFrames},
+		{"sprint", SprintConfig(40), 0},
+		{"ultra", UltraConfig(DefaultUltraTime), 0},
+		{"dig", DigConfig(10, GarbageCheese, 0), 0},
+	}
+
+	for _, tt := range tests {
+		if tt.config.LineClearDelay != tt.want || tt.config.SpawnDelay != 0 {
+			t.Errorf("%s: line clear delay %d, spawn delay %d; want %d and 0",
+				tt.name, tt.config.LineClearDelay, tt.config.SpawnDelay, tt.want)
+		}
+	}
+}
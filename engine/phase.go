@@ -0,0 +1,90 @@
+package engine
+
+// Phase is the part of a piece's life cycle the game is in. Line clears and
+// the entry delay (ARE) between pieces are timed phases, so clients have
+// frames to animate them and modes can reproduce the timings of other games.
+type Phase int
+
+const (
+	PhaseFalling    Phase = iota // The piece is in the air
+	PhaseLocking                 // The piece is grounded and its lock delay is running
+	PhaseLineClear               // Cleared rows stay on the board before the stack collapses
+	PhaseSpawnDelay              // Entry delay (ARE) before the next piece appears
+	PhaseCountdown               // Counting down before the game starts
+	PhaseReady                   // Waiting for the first input to start the game
+)
+
+var phaseNames = [...]string{
+	PhaseFalling:    "falling",
+	PhaseLocking:    "locking",
+	PhaseLineClear:  "line clear",
+	PhaseSpawnDelay: "spawn delay",
+	PhaseCountdown:  "countdown",
+	PhaseReady:      "ready",
+}
+
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return "unknown"
+	}
+	return phaseNames[p]
+}
+
+// Active reports whether a piece is in play and can be moved
+func (p Phase) Active() bool {
+	return p == PhaseFalling || p == PhaseLocking
+}
+
+// Started reports whether the game is under way, after any countdown
+func (p Phase) Started() bool {
+	return p != PhaseCountdown && p != PhaseReady
+}
+
+// updateDelay counts down the countdown, line clear or spawn delay for one
+// frame and moves on to the next phase when it runs out.
+func (g *Game) updateDelay() {
+	if g.Phase == PhaseReady {
+		return
+	}
+
+	g.PhaseTimer--
+	if g.PhaseTimer > 0 {
+		return
+	}
+
+	switch g.Phase {
+	case PhaseCountdown:
+		if g.Config.StartOnInput {
+			g.Phase = PhaseReady
+			return
+		}
+		g.begin()
+	case PhaseLineClear:
+		g.Board.RemoveRows(g.ClearingRows)
+		g.ClearingRows = nil
+		g.startSpawnDelay()
+	case PhaseSpawnDelay:
+		g.spawnPiece(g.nextPiece())
+	}
+}
+
+// begin starts the game timer and deals the first piece
+func (g *Game) begin() {
+	g.startedAt = g.clock.Now()
+	g.startFrame = g.Frame
+	if g.Config.RisingGarbage {
+		g.GarbageTimer = g.RiseInterval()
+	}
+	g.spawnPiece(g.nextPiece())
+}
+
+// startSpawnDelay waits the configured entry delay before the next piece
+// spawns, or spawns it straight away without one.
+func (g *Game) startSpawnDelay() {
+	if g.Config.SpawnDelay <= 0 {
+		g.spawnPiece(g.nextPiece())
+		return
+	}
+	g.Phase = PhaseSpawnDelay
+	g.PhaseTimer = g.Config.SpawnDelay
+}
@@ -0,0 +1,90 @@
+package engine
+
+// Piece is a piece on the board: its type, orientation and the position of
+// the top-left corner of its bounding box. Everything else about the piece
+// comes from the orientation table of its piece set, so pieces are small
+// comparable values and rotating one is an index change.
+type Piece struct {
+	Type     PieceType
+	Rotation Orientation
+	X, Y     int
+}
+
+// orientationState is one orientation of a piece
+type orientationState struct {
+	cells []Point   // Filled cells relative to the top-left of the box
+	size  int       // Width and height of the square bounding box
+	mask  pieceMask // The cells as row bitmasks
+}
+
+func newOrientationState(shape [][]bool) orientationState {
+	state := orientationState{size: len(shape)}
+	for y, row := range shape {
+		for x, filled := range row {
+			if filled {
+				state.cells = append(state.cells, Point{x, y})
+			}
+		}
+	}
+	state.mask = maskOf(state.cells)
+	return state
+}
+
+// rotateShape returns the shape turned a quarter clockwise in its box
+func rotateShape(shape [][]bool) [][]bool {
+	n := len(shape)
+	rotated := make([][]bool, n)
+	for i := range rotated {
+		rotated[i] = make([]bool, n)
+	}
+	for i := range n {
+		for j := range n {
+			rotated[j][n-1-i] = shape[i][j]
+		}
+	}
+	return rotated
+}
+
+// NewPiece returns a piece of the given type in its spawn orientation. Use
+// Board.Spawn to place it at the spawn position of a board.
+func NewPiece(pieceType PieceType) *Piece {
+	return &Piece{Type: pieceType, Rotation: OrientationSpawn}
+}
+
+// Clone returns a copy of the piece, or nil for a nil piece.
+func (p *Piece) Clone() *Piece {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	return &clone
+}
+
+func (p *Piece) Rotate(clockwise bool) {
+	p.Rotation = p.Rotation.Rotate(clockwise)
+}
+
+func (p Piece) state() *orientationState {
+	return &definition(p.Type).states[p.Rotation]
+}
+
+// Cells returns the filled cells of the piece relative to the top-left of
+// its bounding box. The slice is shared and must not be modified.
+func (p Piece) Cells() []Point {
+	return p.state().cells
+}
+
+// Size returns the width and height of the piece's square bounding box.
+func (p Piece) Size() int {
+	return p.state().size
+}
+
+// Color returns the color the piece is drawn in.
+func (p Piece) Color() [3]float32 {
+	return definition(p.Type).color
+}
+
+// Name returns the name of the piece in its piece set.
+func (p Piece) Name() string {
+	return definition(p.Type).name
+}
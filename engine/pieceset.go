@@ -0,0 +1,230 @@
+package engine
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// MaxPieceSize is the largest bounding box a piece definition may use
+const MaxPieceSize = 5
+
+// PieceDef describes one piece of a piece set. Shape is the spawn
+// orientation as a square of rows, with '#' for a filled cell; the other
+// orientations are the shape turned within its box unless Orientations
+// lists them.
+type PieceDef struct {
+	Name         string     `json:"name"`
+	Shape        []string   `json:"shape"`
+	Orientations [][]string `json:"orientations"` // The next three orientations clockwise, or one the piece flips to and back
+	Color        [3]float32 `json:"color"`
+	Kicks        string     `json:"kicks"`       // "srs" (default), "srs-i" or "none"
+	SpawnOffset  Point      `json:"spawnOffset"` // Moves the spawn position, rows grow downwards
+}
+
+// PieceSet is a family of pieces dealt together, such as the seven
+// tetrominoes. Every piece in a registered set has its own PieceType.
+type PieceSet struct {
+	Name  string
+	types []PieceType
+}
+
+// Pieces returns the types of the pieces in the set, in definition order.
+func (s *PieceSet) Pieces() []PieceType {
+	return s.types
+}
+
+// Lookup returns the type of the piece with the given name in the set.
+func (s *PieceSet) Lookup(name string) (PieceType, bool) {
+	for _, t := range s.types {
+		if definition(t).name == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// maxSize returns the largest bounding box of any piece in the set
+func (s *PieceSet) maxSize() int {
+	size := 0
+	for _, t := range s.types {
+		size = max(size, definition(t).states[OrientationSpawn].size)
+	}
+	return size
+}
+
+// pieceDef is a registered piece with its orientations worked out
+type pieceDef struct {
+	name   string
+	color  [3]float32
+	states [4]orientationState
+	kicks  *kickTable
+	spawn  Point // Spawn position relative to the centred column and BufferHeight
+}
+
+// pieceRegistry holds every registered piece, indexed by PieceType. Games
+// read it on every move, so it is published through an atomic pointer and
+// only registration takes the lock.
+var pieceRegistry struct {
+	mu   sync.Mutex
+	defs atomic.Pointer[[]*pieceDef]
+}
+
+func definition(t PieceType) *pieceDef {
+	return (*pieceRegistry.defs.Load())[t]
+}
+
+//go:embed piecesets/*.json
+var builtinPieceSets embed.FS
+
+// The built-in piece sets. Tetrominoes is registered first, so its pieces
+// are PieceI through PieceL.
+var (
+	Tetrominoes = mustLoadBuiltin("tetrominoes")
+	Triominoes  = mustLoadBuiltin("triominoes")
+	Pentominoes = mustLoadBuiltin("pentominoes")
+
+	// NESTetrominoes turn as on the NES: T, J and L spawn pointing down, and
+	// I, S and Z flip between two orientations
+	NESTetrominoes = mustLoadBuiltin("nes")
+)
+
+func mustLoadBuiltin(name string) *PieceSet {
+	f, err := builtinPieceSets.Open("piecesets/" + name + ".json")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	set, err := LoadPieceSet(f)
+	if err != nil {
+		panic(fmt.Sprintf("built-in piece set %s: %v", name, err))
+	}
+	return set
+}
+
+// LoadPieceSet reads a piece set description in JSON and registers it:
+//
+//	{"name": "Triominoes", "pieces": [
+//		{"name": "I3", "shape": ["...", "###", "..."], "color": [0, 0.9, 1]},
+//		{"name": "L3", "shape": ["#.", "##"], "color": [1, 0.3, 0.7]}
+//	]}
+func LoadPieceSet(r io.Reader) (*PieceSet, error) {
+	var description struct {
+		Name   string     `json:"name"`
+		Pieces []PieceDef `json:"pieces"`
+	}
+	if err := json.NewDecoder(r).Decode(&description); err != nil {
+		return nil, fmt.Errorf("reading piece set: %w", err)
+	}
+	return NewPieceSet(description.Name, description.Pieces)
+}
+
+// NewPieceSet checks the piece definitions and registers them as a set.
+func NewPieceSet(name string, defs []PieceDef) (*PieceSet, error) {
+	if len(defs) == 0 {
+		return nil, errors.New("piece set has no pieces")
+	}
+
+	compiled := make([]*pieceDef, len(defs))
+	for i, def := range defs {
+		d, err := compilePiece(def)
+		if err != nil {
+			return nil, fmt.Errorf("piece %d (%s): %w", i, def.Name, err)
+		}
+		compiled[i] = d
+	}
+
+	pieceRegistry.mu.Lock()
+	defer pieceRegistry.mu.Unlock()
+
+	var registered []*pieceDef
+	if current := pieceRegistry.defs.Load(); current != nil {
+		registered = *current
+	}
+
+	set := &PieceSet{Name: name}
+	all := append(registered[:len(registered):len(registered)], compiled...)
+	for i := range compiled {
+		set.types = append(set.types, PieceType(len(registered)+i))
+	}
+	pieceRegistry.defs.Store(&all)
+	return set, nil
+}
+
+func compilePiece(def PieceDef) (*pieceDef, error) {
+	size := len(def.Shape)
+	if size == 0 || size > MaxPieceSize {
+		return nil, fmt.Errorf("shape must have 1 to %d rows", MaxPieceSize)
+	}
+	shape, lowest, err := parseShape(def.Shape, size)
+	if err != nil {
+		return nil, err
+	}
+
+	kicks, ok := kickTables[def.Kicks]
+	if !ok {
+		return nil, fmt.Errorf("unknown kick table %q", def.Kicks)
+	}
+
+	d := &pieceDef{
+		name:  def.Name,
+		color: def.Color,
+		kicks: kicks,
+		// The lowest blocks spawn in the row just above the playfield
+		spawn: Point{X: def.SpawnOffset.X, Y: def.SpawnOffset.Y - 1 - lowest},
+	}
+	if def.Orientations == nil {
+		for r := range d.states {
+			d.states[r] = newOrientationState(shape)
+			shape = rotateShape(shape)
+		}
+		return d, nil
+	}
+
+	if n := len(def.Orientations); n != 1 && n != 3 {
+		return nil, errors.New("orientations must list one or three shapes")
+	}
+	shapes := [][][]bool{shape}
+	for i, rows := range def.Orientations {
+		turned, _, err := parseShape(rows, size)
+		if err != nil {
+			return nil, fmt.Errorf("orientation %d: %w", i+1, err)
+		}
+		shapes = append(shapes, turned)
+	}
+	for r := range d.states {
+		d.states[r] = newOrientationState(shapes[r%len(shapes)])
+	}
+	return d, nil
+}
+
+// parseShape reads a square of rows of the given size, returning the filled
+// cells and the lowest row with one
+func parseShape(rows []string, size int) (shape [][]bool, lowest int, err error) {
+	if len(rows) != size {
+		return nil, 0, fmt.Errorf("shape must have %d rows, not %d", size, len(rows))
+	}
+	shape = make([][]bool, size)
+	lowest = -1
+	for y, row := range rows {
+		if len(row) != size {
+			return nil, 0, fmt.Errorf("shape must be square, row %d has %d cells", y, len(row))
+		}
+		shape[y] = make([]bool, size)
+		for x, ch := range row {
+			if ch == '#' {
+				shape[y][x] = true
+				lowest = y
+			}
+		}
+	}
+	if lowest < 0 {
+		return nil, 0, errors.New("shape has no filled cells")
+	}
+	return shape, lowest, nil
+}
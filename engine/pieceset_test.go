@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadPieceSet(t *testing.T) {
+	set, err := LoadPieceSet(strings.NewReader(`{"name": "Dominoes", "pieces": [
+		{"name": "D", "shape": ["##", ".."], "color": [1, 1, 1], "kicks": "none", "spawnOffset": {"x": 1}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	board := NewBoard(DefaultWidth, DefaultHeight)
+	piece := board.Spawn(set.Pieces()[0])
+	if piece.Name() != "D" || piece.X != 5 || piece.Y != BufferHeight-1 {
+		t.Errorf("domino spawned as %s at (%d, %d), want D at (5, %d)", piece.Name(), piece.X, piece.Y, BufferHeight-1)
+	}
+
+	piece.Rotate(true)
+	if got := piece.Cells(); len(got) != 2 || got[0] != (Point{1, 0}) || got[1] != (Point{1, 1}) {
+		t.Errorf("rotated domino cells = %v", got)
+	}
+}
+
+func TestLoadPieceSetErrors(t *testing.T) {
+	for _, description := range []string{
+		`{"pieces": []}`,
+		`{"pieces": [{"shape": ["##", "#"]}]}`,
+		`{"pieces": [{"shape": ["..", ".."]}]}`,
+		`{"pieces": [{"shape": ["######"]}]}`,
+		`{"pieces": [{"shape": ["#"], "kicks": "sideways"}]}`,
+		`{"pieces": [{"shape": ["#."], "orientations": [[".#", ".."], ["#.", ".."]]}]}`,
+		`{"pieces": [{"shape": ["#.", ".."], "orientations": [["#"]]}]}`,
+		`{"pieces": [{"shape": ["#.", ".."], "orientations": [["..", ".."]]}]}`,
+	} {
+		if _, err := LoadPieceSet(strings.NewReader(description)); err == nil {
+			t.Errorf("LoadPieceSet(%s) succeeded, want an error", description)
+		}
+	}
+}
+
+func TestPlayWithPieceSets(t *testing.T) {
+	for _, set := range []*PieceSet{Triominoes, Pentominoes} {
+		config := DefaultConfig()
+		config.PieceSet = set
+		g := NewGame(config)
+
+		for i := 0; i < 50 && !g.GameOver; i++ {
+			if !contains(set.Pieces(), g.CurrentPiece.Type) {
+				t.Fatalf("%s game dealt a %s", set.Name, g.CurrentPiece.Name())
+			}
+			g.Apply([]Action{ActionRotateCW, ActionMoveLeft, ActionRotateCCW, ActionMoveRight}[i%4])
+			g.HardDrop()
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Objective is what a puzzle asks the player to achieve
+type Objective int
+
+const (
+	ObjectiveLines        Objective = iota // Clear a number of lines
+	ObjectiveTSpinDouble                   // Clear two lines with a T-spin
+	ObjectivePerfectClear                  // Clear lines leaving the board empty
+)
+
+// objectiveNames maps the objectives of puzzle files to Objectives
+var objectiveNames = map[string]Objective{
+	"lines":         ObjectiveLines,
+	"tsd":           ObjectiveTSpinDouble,
+	"perfect-clear": ObjectivePerfectClear,
+}
+
+// maxPuzzlePieces is the longest puzzle queue; the whole queue fits in the
+// preview alongside the current piece
+const maxPuzzlePieces = MaxPreviews + 1
+
+// PuzzleDef describes one puzzle of a puzzle pack. Board holds the bottom
+// rows of the playfield, top first: '.' is empty, '#' is garbage and a
+// tetromino's name is a block in that piece's color.
+type PuzzleDef struct {
+	Name      string   `json:"name"`
+	Board     []string `json:"board"`
+	Queue     []string `json:"queue"`     // Tetromino names, dealt in order
+	Hold      bool     `json:"hold"`      // The hold box can be used
+	Objective string   `json:"objective"` // "lines" (default), "tsd" or "perfect-clear"
+	Lines     int      `json:"lines"`     // Lines to clear for the "lines" objective
+	Pieces    int      `json:"pieces"`    // Pieces allowed; 0 allows the whole queue
+}
+
+// Puzzle is a fixed position to solve: a starting board, the pieces to
+// place and what to achieve with them. Puzzles are played with the
+// Tetrominoes.
+type Puzzle struct {
+	Name      string
+	Board     []string    // Bottom rows of the playfield, top first
+	Queue     []PieceType // Pieces dealt, in order
+	Hold      bool        // The hold box can be used
+	Objective Objective
+	Lines     int // Lines to clear for ObjectiveLines
+	Pieces    int // Pieces the objective must be achieved with
+}
+
+// Width returns the number of board columns the puzzle is played on.
+func (p *Puzzle) Width() int {
+	if len(p.Board) == 0 {
+		return DefaultWidth
+	}
+	return len(p.Board[0])
+}
+
+// Goal describes the objective, such as "clear 4 lines".
+func (p *Puzzle) Goal() string {
+	switch p.Objective {
+	case ObjectiveTSpinDouble:
+		return "t-spin double"
+	case ObjectivePerfectClear:
+		return "perfect clear"
+	}
+	if p.Lines == 1 {
+		return "clear 1 line"
+	}
+	return fmt.Sprintf("clear %d lines", p.Lines)
+}
+
+// PuzzlePack is a named list of puzzles
+type PuzzlePack struct {
+	Name    string
+	Puzzles []*Puzzle
+}
+
+//go:embed puzzles/*.json
+var builtinPuzzles embed.FS
+
+// BasicPuzzles is the built-in puzzle pack
+var BasicPuzzles = mustLoadBuiltinPuzzles("basics")
+
+func mustLoadBuiltinPuzzles(name string) *PuzzlePack {
+	f, err := builtinPuzzles.Open("puzzles/" + name + ".json")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	pack, err := LoadPuzzlePack(f)
+	if err != nil {
+		panic(fmt.Sprintf("built-in puzzle pack %s: %v", name, err))
+	}
+	return pack
+}
+
+// LoadPuzzlePack reads a puzzle pack description in JSON:
+//
+//	{"name": "Basics", "puzzles": [
+//		{"name": "Tetris", "board": ["#########.", "#########."],
+//		 "queue": ["I"], "objective": "lines", "lines": 2}
+//	]}
+func LoadPuzzlePack(r io.Reader) (*PuzzlePack, error) {
+	var description struct {
+		Name    string      `json:"name"`
+		Puzzles []PuzzleDef `json:"puzzles"`
+	}
+	if err := json.NewDecoder(r).Decode(&description); err != nil {
+		return nil, fmt.Errorf("reading puzzle pack: %w", err)
+	}
+	if len(description.Puzzles) == 0 {
+		return nil, errors.New("puzzle pack has no puzzles")
+	}
+
+	pack := &PuzzlePack{Name: description.Name}
+	for i, def := range description.Puzzles {
+		puzzle, err := NewPuzzle(def)
+		if err != nil {
+			return nil, fmt.Errorf("puzzle %d (%s): %w", i+1, def.Name, err)
+		}
+		pack.Puzzles = append(pack.Puzzles, puzzle)
+	}
+	return pack, nil
+}
+
+// NewPuzzle checks a puzzle definition and turns it into a Puzzle.
+func NewPuzzle(def PuzzleDef) (*Puzzle, error) {
+	p := &Puzzle{
+		Name:   def.Name,
+		Board:  def.Board,
+		Hold:   def.Hold,
+		Lines:  def.Lines,
+		Pieces: def.Pieces,
+	}
+
+	width := p.Width()
+	if width < MinWidth || width > MaxWidth {
+		return nil, fmt.Errorf("board must be %d to %d columns wide", MinWidth, MaxWidth)
+	}
+	if len(def.Board) > MaxHeight {
+		return nil, fmt.Errorf("board must have at most %d rows", MaxHeight)
+	}
+	for y, row := range def.Board {
+		if len(row) != width {
+			return nil, fmt.Errorf("board row %d is %d columns wide, want %d", y+1, len(row), width)
+		}
+		if !strings.Contains(row, ".") {
+			return nil, fmt.Errorf("board row %d is full", y+1)
+		}
+		for _, c := range row {
+			if _, ok := cellColor(c); !ok && c != '.' {
+				return nil, fmt.Errorf("board row %d: unknown cell %q", y+1, c)
+			}
+		}
+	}
+
+	if len(def.Queue) == 0 || len(def.Queue) > maxPuzzlePieces {
+		return nil, fmt.Errorf("queue must have 1 to %d pieces", maxPuzzlePieces)
+	}
+	for _, name := range def.Queue {
+		t, ok := Tetrominoes.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown piece %q", name)
+		}
+		p.Queue = append(p.Queue, t)
+	}
+
+	switch {
+	case p.Pieces == 0:
+		p.Pieces = len(p.Queue)
+	case p.Pieces < 0 || p.Pieces > len(p.Queue):
+		return nil, fmt.Errorf("pieces must be 1 to %d, the length of the queue", len(p.Queue))
+	}
+
+	objective := def.Objective
+	if objective == "" {
+		objective = "lines"
+	}
+	var ok bool
+	if p.Objective, ok = objectiveNames[objective]; !ok {
+		return nil, fmt.Errorf("unknown objective %q", def.Objective)
+	}
+	if p.Objective == ObjectiveLines && p.Lines <= 0 {
+		return nil, errors.New("lines objective needs a positive number of lines")
+	}
+	return p, nil
+}
+
+// cellColor returns the color of a filled puzzle board cell: '#' for
+// garbage or the name of a tetromino
+func cellColor(c rune) ([3]float32, bool) {
+	if c == '#' {
+		return garbageColor, true
+	}
+	if t, ok := Tetrominoes.Lookup(string(c)); ok {
+		return definition(t).color, true
+	}
+	return [3]float32{}, false
+}
+
+// fill lays the puzzle's rows out along the bottom of the board
+func (p *Puzzle) fill(b *Board) {
+	top := b.Rows() - len(p.Board)
+	for i, row := range p.Board {
+		for x, c := range row {
+			if color, ok := cellColor(c); ok {
+				b.Fill(x, top+i, color)
+			}
+		}
+	}
+}
+
+// nextPuzzlePiece deals the next piece of the puzzle's fixed queue. Once
+// the queue is empty the held piece comes out of the hold box, and with
+// neither left there is no piece to deal.
+func (g *Game) nextPuzzlePiece() *Piece {
+	if len(g.Queue) == 0 {
+		held := g.HeldPiece
+		if held == nil {
+			return nil
+		}
+		g.HeldPiece = nil
+		return g.Board.Spawn(held.Type)
+	}
+	next := g.Queue[0]
+	g.Queue = g.Queue[1:]
+	return next
+}
+
+// puzzleOutcome reports whether the last lock solved the puzzle, or failed
+// it by using up the pieces allowed
+func (g *Game) puzzleOutcome() GameOverReason {
+	p := g.Config.Puzzle
+	solved := false
+	switch p.Objective {
+	case ObjectiveLines:
+		solved = g.Lines >= p.Lines
+	case ObjectiveTSpinDouble:
+		solved = g.LastLock.Spin == SpinFull && g.LastLock.Lines == 2
+	case ObjectivePerfectClear:
+		solved = g.LastLock.PerfectClear
+	}
+
+	switch {
+	case solved:
+		return GameOverSolved
+	case g.Pieces >= p.Pieces:
+		return GameOverFailed
+	}
+	return GameOverNone
+}
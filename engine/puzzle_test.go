@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// actionDrop drops the piece to the floor without locking it, as holding
+// soft drop does
+const actionDrop Action = -1
+
+func play(g *Game, actions ...Action) {
+	for _, a := range actions {
+		if a == actionDrop {
+			g.MovePiece(0, g.Board.DropDistance(g.CurrentPiece))
+			continue
+		}
+		g.Apply(a)
+	}
+}
+
+func TestBasicPuzzles(t *testing.T) {
+	solutions := map[string][]Action{
+		"First Tetris": {ActionRotateCW, ActionMoveRight, ActionMoveRight, ActionMoveRight, ActionMoveRight, ActionHardDrop},
+		"Step Down":    {ActionRotateCW, ActionHardDrop},
+		"Hold It": {ActionHold, ActionRotateCCW, ActionMoveLeft, ActionMoveLeft, ActionMoveLeft,
+			ActionMoveLeft, ActionHardDrop},
+		"T Slot":    {ActionRotateCCW, actionDrop, ActionRotateCCW, ActionHardDrop},
+		"All Clear": {ActionHold, ActionHardDrop, ActionRotateCW, ActionRotateCW, ActionMoveLeft, ActionHardDrop},
+	}
+
+	for _, p := range BasicPuzzles.Puzzles {
+		solution, ok := solutions[p.Name]
+		if !ok {
+			t.Errorf("no solution for puzzle %q", p.Name)
+			continue
+		}
+		g := NewGame(PuzzleConfig(p))
+		play(g, solution...)
+		if g.GameOverWhy != GameOverSolved {
+			t.Errorf("%s: game over %q after the solution, want solved", p.Name, g.GameOverWhy)
+		}
+	}
+}
+
+func TestPuzzleFailed(t *testing.T) {
+	p := BasicPuzzles.Puzzles[1]
+	g := NewGame(PuzzleConfig(p))
+	if want := boardFromRows(p.Board...); !slices.Equal(g.Board.rows, want.rows) {
+		t.Errorf("puzzle board does not match %q", p.Board)
+	}
+
+	names, _ := recordEvents(g)
+	play(g, ActionHardDrop)
+	if !g.GameOver || g.GameOverWhy != GameOverFailed || g.GameOverWhy.ToppedOut() {
+		t.Errorf("game over %q after a wasted piece, want failed", g.GameOverWhy)
+	}
+	if last := (*names)[len(*names)-1]; last != "Finished" {
+		t.Errorf("last event %s, want Finished", last)
+	}
+}
+
+func TestPuzzleHold(t *testing.T) {
+	p, err := NewPuzzle(PuzzleDef{Queue: []string{"T"}, Hold: true, Objective: "perfect-clear"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := NewGame(PuzzleConfig(p)); g.Apply(ActionHold) {
+		t.Error("held the only piece of a puzzle")
+	}
+
+	// Puzzles without hold never allow it
+	g := NewGame(PuzzleConfig(BasicPuzzles.Puzzles[3]))
+	if g.Apply(ActionHold) {
+		t.Error("held a piece in a puzzle without hold")
+	}
+
+	// A held piece does not count towards the piece limit
+	g = NewGame(PuzzleConfig(BasicPuzzles.Puzzles[2]))
+	if !g.Apply(ActionHold) || g.CurrentPiece.Type != PieceI || len(g.Queue) != 0 {
+		t.Fatalf("hold dealt %s leaving %d queued, want I leaving none", g.CurrentPiece.Name(), len(g.Queue))
+	}
+	play(g, ActionHardDrop)
+	if g.GameOverWhy != GameOverFailed {
+		t.Errorf("game over %q after the last allowed piece, want failed", g.GameOverWhy)
+	}
+}
+
+func TestPuzzleHeldPieceComesOut(t *testing.T) {
+	p, err := NewPuzzle(PuzzleDef{Queue: []string{"O", "I"}, Hold: true, Objective: "perfect-clear"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(PuzzleConfig(p))
+
+	// With the queue used up the held O is dealt, never a random piece
+	play(g, ActionHold, ActionHardDrop)
+	if g.GameOver || g.CurrentPiece.Type != PieceO || g.HeldPiece != nil {
+		t.Fatalf("dealt %s with %v held after the queue ran out, want the held O", g.CurrentPiece.Name(), g.HeldPiece)
+	}
+	if g.Apply(ActionHold) {
+		t.Error("held the last piece of a puzzle")
+	}
+	play(g, ActionHardDrop)
+	if g.GameOverWhy != GameOverFailed {
+		t.Errorf("game over %q after every piece, want failed", g.GameOverWhy)
+	}
+}
+
+func TestPuzzleRunsOutOfPieces(t *testing.T) {
+	// A puzzle built by hand can allow more pieces than it deals
+	p := &Puzzle{Queue: []PieceType{PieceI}, Hold: true, Objective: ObjectiveLines, Lines: 4, Pieces: 2}
+	g := NewGame(PuzzleConfig(p))
+
+	play(g, ActionHardDrop)
+	if !g.GameOver || g.GameOverWhy != GameOverFailed {
+		t.Errorf("game over %v (%q) with no piece left, want failed", g.GameOver, g.GameOverWhy)
+	}
+}
+
+func TestLoadPuzzlePackErrors(t *testing.T) {
+	for _, description := range []string{
+		`{"puzzles": []}`,
+		`{"puzzles": [{"queue": ["T"], "objective": "lines"}]}`,
+		`{"puzzles": [{"queue": [], "objective": "tsd"}]}`,
+		`{"puzzles": [{"queue": ["T2"], "objective": "tsd"}]}`,
+		`{"puzzles": [{"queue": ["T"], "objective": "tst"}]}`,
+		`{"puzzles": [{"queue": ["T"], "objective": "tsd", "pieces": 2}]}`,
+		`{"puzzles": [{"queue": ["T"], "objective": "tsd", "board": ["#########.", "##"]}]}`,
+		`{"puzzles": [{"queue": ["T"], "objective": "tsd", "board": ["##########"]}]}`,
+		`{"puzzles": [{"queue": ["T"], "objective": "tsd", "board": ["#########?"]}]}`,
+		`{"puzzles": [{"queue": ["I", "O", "T", "S", "Z", "J", "L", "I", "O"], "objective": "tsd"}]}`,
+	} {
+		if _, err := LoadPuzzlePack(strings.NewReader(description)); err == nil {
+			t.Errorf("LoadPuzzlePack(%s) succeeded, want an error", description)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package engine
+
+import "math/rand"
+
+// Randomizer produces the sequence of pieces dealt to the player.
+// Implementations must draw all randomness from the rng they were created
+// with so a game is reproducible from its seed.
+type Randomizer interface {
+	Next() PieceType
+}
+
+// RandomizerKind selects one of the built-in piece generators
+type RandomizerKind int
+
+const (
+	RandomizerBag7    RandomizerKind = iota // Guideline 7-bag
+	RandomizerBag14                         // Two copies of each piece per bag
+	RandomizerHistory                       // TGM-style 4-piece history with rerolls
+	RandomizerNES                           // NES: reroll once on a repeat
+	RandomizerUniform                       // Memoryless uniform random
+)
+
+// historyRolls is the number of attempts the history randomizer makes to
+// find a piece that is not in its recent history.
+const historyRolls = 6
+
+// NewRandomizer creates the generator of the given kind dealing the given
+// pieces and drawing from rng.
+func NewRandomizer(kind RandomizerKind, rng *rand.Rand, pieces []PieceType) Randomizer {
+	switch kind {
+	case RandomizerBag14:
+		return NewBagRandomizer(rng, pieces, 2)
+	case RandomizerHistory:
+		return NewHistoryRandomizer(rng, pieces, 4, historyRolls)
+	case RandomizerNES:
+		return NewNESRandomizer(rng, pieces)
+	case RandomizerUniform:
+		return NewUniformRandomizer(rng, pieces)
+	default:
+		return NewBagRandomizer(rng, pieces, 1)
+	}
+}
+
+// contains reports whether piece is one of pieces
+func contains(pieces []PieceType, piece PieceType) bool {
+	for _, p := range pieces {
+		if p == piece {
+			return true
+		}
+	}
+	return false
+}
+
+// uniformRandomizer picks every piece independently
+type uniformRandomizer struct {
+	rng    *rand.Rand
+	pieces []PieceType
+}
+
+// NewUniformRandomizer returns a memoryless generator where every piece is
+// equally likely on every draw.
+func NewUniformRandomizer(rng *rand.Rand, pieces []PieceType) Randomizer {
+	return &uniformRandomizer{rng: rng, pieces: pieces}
+}
+
+func (r *uniformRandomizer) Next() PieceType {
+	return r.pieces[r.rng.Intn(len(r.pieces))]
+}
+
+// bagRandomizer deals shuffled bags holding a fixed number of copies of
+// every piece
+type bagRandomizer struct {
+	rng    *rand.Rand
+	pieces []PieceType
+	copies int
+	bag    []PieceType
+}
+
+// NewBagRandomizer returns a generator that shuffles copies of each piece
+// into a bag and deals the whole bag before refilling it. One copy gives the
+// guideline 7-bag, two copies a 14-bag.
+func NewBagRandomizer(rng *rand.Rand, pieces []PieceType, copies int) Randomizer {
+	if copies < 1 {
+		copies = 1
+	}
+	return &bagRandomizer{rng: rng, pieces: pieces, copies: copies}
+}
+
+func (r *bagRandomizer) Next() PieceType {
+	if len(r.bag) == 0 {
+		r.refill()
+	}
+
+	piece := r.bag[0]
+	r.bag = r.bag[1:]
+	return piece
+}
+
+func (r *bagRandomizer) refill() {
+	r.bag = make([]PieceType, 0, len(r.pieces)*r.copies)
+	for range r.copies {
+		r.bag = append(r.bag, r.pieces...)
+	}
+	r.rng.Shuffle(len(r.bag), func(i, j int) {
+		r.bag[i], r.bag[j] = r.bag[j], r.bag[i]
+	})
+}
+
+// historyRandomizer rerolls pieces that appear in its recent history, as in
+// The Grand Master series
+type historyRandomizer struct {
+	rng     *rand.Rand
+	pieces  []PieceType
+	rolls   int
+	history []PieceType
+	first   bool
+}
+
+// historyAvoidFirst are the tetrominoes the history randomizer never deals
+// first, since they leave an overhang on an empty board
+var historyAvoidFirst = []PieceType{PieceS, PieceZ, PieceO}
+
+// NewHistoryRandomizer returns a TGM-style generator remembering the last
+// size pieces. Each draw rerolls up to rolls times while the candidate is in
+// the history. With the tetrominoes the history starts filled with S and Z,
+// and the first piece is never S, Z or O.
+func NewHistoryRandomizer(rng *rand.Rand, pieces []PieceType, size, rolls int) Randomizer {
+	// Without S and Z in the set the history starts out matching no piece
+	start := [2]PieceType{-1, -1}
+	if contains(pieces, PieceS) && contains(pieces, PieceZ) {
+		start = [2]PieceType{PieceZ, PieceS}
+	}
+	history := make([]PieceType, size)
+	for i := range history {
+		history[i] = start[i%2]
+	}
+
+	// The first piece rule only applies while some other piece can start
+	first := false
+	for _, p := range pieces {
+		if !contains(historyAvoidFirst, p) {
+			first = true
+		}
+	}
+	return &historyRandomizer{rng: rng, pieces: pieces, rolls: rolls, history: history, first: first}
+}
+
+func (r *historyRandomizer) Next() PieceType {
+	var piece PieceType
+	if r.first {
+		r.first = false
+		for {
+			piece = r.roll()
+			if !contains(historyAvoidFirst, piece) {
+				break
+			}
+		}
+	} else {
+		for range r.rolls {
+			piece = r.roll()
+			if !contains(r.history, piece) {
+				break
+			}
+		}
+	}
+
+	copy(r.history, r.history[1:])
+	r.history[len(r.history)-1] = piece
+	return piece
+}
+
+func (r *historyRandomizer) roll() PieceType {
+	return r.pieces[r.rng.Intn(len(r.pieces))]
+}
+
+// nesRandomizer reproduces the NES generator, which rolls one extra
+// "dummy" value and rerolls once when it gets the dummy or a repeat
+type nesRandomizer struct {
+	rng    *rand.Rand
+	pieces []PieceType
+	last   PieceType
+	have   bool
+}
+
+// NewNESRandomizer returns the NES generator: a repeat of the previous piece
+// triggers exactly one reroll, whose result is always accepted.
+func NewNESRandomizer(rng *rand.Rand, pieces []PieceType) Randomizer {
+	return &nesRandomizer{rng: rng, pieces: pieces}
+}
+
+func (r *nesRandomizer) Next() PieceType {
+	n := len(r.pieces)
+	roll := r.rng.Intn(n + 1)
+	if roll == n || (r.have && r.pieces[roll] == r.last) {
+		roll = r.rng.Intn(n)
+	}
+
+	r.last = r.pieces[roll]
+	r.have = true
+	return r.last
+}
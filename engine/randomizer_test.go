@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// rolls is a rand.Source that returns set values, so Intn(n) draws each
+// value in turn (modulo n)
+type rolls []int64
+
+func (r *rolls) Int63() int64 {
+	v := (*r)[0]
+	*r = (*r)[1:]
+	return v << 32 // Int31 keeps the top bits
+}
+
+func (r *rolls) Seed(int64) {}
+
+// rolled returns a rand.Rand drawing the given values
+func rolled(values ...int64) *rand.Rand {
+	r := rolls(values)
+	return rand.New(&r)
+}
+
+func TestBagRandomizers(t *testing.T) {
+	tests := []struct {
+		kind   RandomizerKind
+		pieces *PieceSet
+		copies int
+	}{
+		{RandomizerBag7, Tetrominoes, 1},
+		{RandomizerBag14, Tetrominoes, 2},
+		{RandomizerBag7, Pentominoes, 1},
+	}
+
+	for _, tt := range tests {
+		pieces := tt.pieces.Pieces()
+		r := NewRandomizer(tt.kind, rand.New(rand.NewSource(1)), pieces)
+		for bag := range 10 {
+			counts := map[PieceType]int{}
+			for range len(pieces) * tt.copies {
+				counts[r.Next()]++
+			}
+			for _, p := range pieces {
+				if counts[p] != tt.copies {
+					t.Errorf("kind %d bag %d dealt piece %d %d times, want %d", tt.kind, bag, p, counts[p], tt.copies)
+				}
+			}
+		}
+	}
+}
+
+func TestHistoryRandomizer(t *testing.T) {
+	tests := []struct {
+		name  string
+		rolls []int64
+		want  []PieceType
+	}{
+		// The history starts as Z S Z S, and S, Z and O never come first
+		{"first piece", []int64{int64(PieceS), int64(PieceZ), int64(PieceO), int64(PieceS), int64(PieceT)}, []PieceType{PieceT}},
+		{"first I", []int64{int64(PieceI)}, []PieceType{PieceI}},
+		{"reroll", []int64{int64(PieceJ), int64(PieceJ), int64(PieceS), int64(PieceL)}, []PieceType{PieceJ, PieceL}},
+		{"out of rerolls", []int64{int64(PieceL), int64(PieceL), int64(PieceZ), int64(PieceS), int64(PieceL), int64(PieceZ), int64(PieceS)},
+			[]PieceType{PieceL, PieceS}},
+	}
+
+	for _, tt := range tests {
+		r := NewRandomizer(RandomizerHistory, rolled(tt.rolls...), Tetrominoes.Pieces())
+		var got []PieceType
+		for range tt.want {
+			got = append(got, r.Next())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: dealt %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNESRandomizer(t *testing.T) {
+	tests := []struct {
+		name  string
+		rolls []int64
+		want  []PieceType
+	}{
+		{"no repeat", []int64{int64(PieceT), int64(PieceJ)}, []PieceType{PieceT, PieceJ}},
+		{"dummy", []int64{7, int64(PieceO)}, []PieceType{PieceO}},
+		{"one reroll", []int64{int64(PieceT), int64(PieceT), int64(PieceI)}, []PieceType{PieceT, PieceI}},
+		{"reroll kept", []int64{int64(PieceT), int64(PieceT), int64(PieceT)}, []PieceType{PieceT, PieceT}},
+	}
+
+	for _, tt := range tests {
+		r := NewRandomizer(RandomizerNES, rolled(tt.rolls...), Tetrominoes.Pieces())
+		var got []PieceType
+		for range tt.want {
+			got = append(got, r.Next())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: dealt %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRandomizerSeeds(t *testing.T) {
+	kinds := []RandomizerKind{RandomizerBag7, RandomizerBag14, RandomizerHistory, RandomizerNES, RandomizerUniform}
+
+	for _, kind := range kinds {
+		a := NewRandomizer(kind, rand.New(rand.NewSource(42)), Tetrominoes.Pieces())
+		b := NewRandomizer(kind, rand.New(rand.NewSource(42)), Tetrominoes.Pieces())
+		for i := range 200 {
+			if pa, pb := a.Next(), b.Next(); pa != pb {
+				t.Errorf("kind %d piece %d differs with the same seed: %d and %d", kind, i, pa, pb)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+// NESMaxStartLevel is the highest level the NES level select offers
+const NESMaxStartLevel = 19
+
+// NES timings in frames
+const (
+	nesDAS            = 16
+	nesARR            = 6
+	nesSpawnDelay     = 10 // Shortest NES entry delay, for pieces locked at the bottom
+	nesLineClearDelay = 18 // The NES line clear animation takes 17 to 20 frames
+)
+
+// nesSoftDropGravity is the NES soft drop speed, a row every other frame
+const nesSoftDropGravity = 0.5
+
+// NESRules returns config changed to play by the rules of NES Tetris from
+// the given start level: no hold, hard drop or ghost piece and one preview,
+// the NES pieces turning without kicks and appearing without a spawn drop,
+// the NES randomizer, gravity table, scoring and level transitions, no lock
+// delay, 16/6 frame auto-shift and a soft drop of a row every two frames.
+// The mode's goals are kept; as NES levels count from the start level, a
+// final level becomes the lines it took.
+func NESRules(config Config, startLevel int) Config {
+	if config.FinalLevel > 0 {
+		levels := config.FinalLevel - max(config.StartLevel, 1) + 1
+		config.LineGoal = levels * linesPerLevel
+		config.FinalLevel = 0
+	}
+
+	config.Randomizer = RandomizerNES
+	config.Scoring = ScoringNES
+	config.Speed = SpeedNES
+	config.LevelGoal = LevelGoalNES
+	config.StartLevel = min(max(startLevel, 0), NESMaxStartLevel)
+
+	config.PieceSet = NESTetrominoes
+	config.Previews = 1
+	config.NoHold = true
+	config.NoHardDrop = true
+	config.NoGhost = true
+	config.NoKicks = true
+	config.NoSpawnDrop = true
+	config.LockReset = LockOnGravity
+
+	config.DAS = nesDAS
+	config.ARR = nesARR
+	config.SoftDropGravity = nesSoftDropGravity
+	config.SpawnDelay = nesSpawnDelay
+	config.LineClearDelay = nesLineClearDelay
+	return config
+}
@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNESGravity(t *testing.T) {
+	for level, want := range map[int]int{0: 48, 8: 8, 9: 6, 18: 3, 19: 2, 28: 2, 29: 1, 40: 1} {
+		g := NewGame(NESRules(DefaultConfig(), 0))
+		g.Level = level
+		g.updateGravity()
+
+		// Count the frames until gravity moves the spawned piece a row
+		y, frames := g.CurrentPiece.Y, 0
+		for g.CurrentPiece.Y == y && frames < 100 {
+			g.Step()
+			frames++
+		}
+		if frames != want {
+			t.Errorf("level %d: a row every %d frames, want %d", level, frames, want)
+		}
+	}
+}
+
+func TestNESLevels(t *testing.T) {
+	tests := []struct {
+		start, first int // Start level and lines to its first level up
+	}{
+		{0, 10},
+		{5, 60},
+		{9, 100},
+		{12, 100},
+		{15, 100},
+		{18, 130},
+		{19, 140},
+	}
+
+	for _, tt := range tests {
+		g := NewGame(NESRules(MarathonConfig(150, LevelGoalFixed), tt.start))
+		if g.Level != tt.start {
+			t.Errorf("start level %d: started on level %d", tt.start, g.Level)
+		}
+
+		g.Lines = tt.first - 1
+		g.updateLevel()
+		if g.Level != tt.start {
+			t.Errorf("start level %d: level %d after %d lines", tt.start, g.Level, g.Lines)
+		}
+		g.Lines = tt.first
+		g.updateLevel()
+		if g.Level != tt.start+1 {
+			t.Errorf("start level %d: level %d after %d lines, want %d", tt.start, g.Level, g.Lines, tt.start+1)
+		}
+		g.Lines = tt.first + 10
+		g.updateLevel()
+		if g.Level != tt.start+2 || g.GameOver {
+			t.Errorf("start level %d: level %d after %d lines, want %d", tt.start, g.Level, g.Lines, tt.start+2)
+		}
+	}
+}
+
+func TestNESFixedLevel(t *testing.T) {
+	for _, start := range []int{0, 1, 5, 19} {
+		g := NewGame(NESRules(SprintConfig(40), start))
+		gravity := g.Gravity
+		g.Lines = 200
+		g.updateLevel()
+		if g.Level != start || g.Gravity != gravity {
+			t.Errorf("sprint from level %d: level %d at gravity %v after 200 lines, want it unchanged", start, g.Level, g.Gravity)
+		}
+	}
+}
+
+func TestNESRules(t *testing.T) {
+	g := NewGame(NESRules(DefaultConfig(), 0))
+	if len(g.Queue) != 1 {
+		t.Errorf("%d pieces previewed, want 1", len(g.Queue))
+	}
+	if g.Apply(ActionHold) {
+		t.Error("held a piece under NES rules")
+	}
+	if y := g.CurrentPiece.Y; g.Apply(ActionHardDrop) || g.CurrentPiece.Y != y || g.Pieces != 0 {
+		t.Error("hard dropped a piece under NES rules")
+	}
+
+	// A T against the left wall cannot kick right to turn
+	nesT, _ := NESTetrominoes.Lookup("T")
+	g.CurrentPiece = g.Board.Spawn(nesT)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X, g.CurrentPiece.Y = -1, BufferHeight
+	if g.Apply(ActionRotateCW) {
+		t.Error("T kicked off the wall under NES rules")
+	}
+
+	// A Tetris on level 0 scores 1200
+	nesI, _ := NESTetrominoes.Lookup("I")
+	g.Board = boardFromRows("#########.", "#########.", "#########.", "#########.")
+	g.CurrentPiece = g.Board.Spawn(nesI)
+	g.CurrentPiece.Rotate(true)
+	g.CurrentPiece.X = 7
+	g.HardDrop()
+	if g.LastLock.Points != 1200 {
+		t.Errorf("level 0 Tetris scored %d, want 1200", g.LastLock.Points)
+	}
+}
+
+func TestNESPieces(t *testing.T) {
+	tests := []struct {
+		name  string
+		spawn []Point // Board cells at spawn, relative to the top left of the playfield
+		turns [4][]Point
+	}{
+		{"T", []Point{{4, 0}, {5, 0}, {6, 0}, {5, 1}}, [4][]Point{
+			{{0, 1}, {1, 1}, {2, 1}, {1, 2}},
+			{{1, 0}, {0, 1}, {1, 1}, {1, 2}},
+			{{1, 0}, {0, 1}, {1, 1}, {2, 1}},
+			{{1, 0}, {1, 1}, {2, 1}, {1, 2}},
+		}},
+		{"I", []Point{{3, 0}, {4, 0}, {5, 0}, {6, 0}}, [4][]Point{
+			{{0, 2}, {1, 2}, {2, 2}, {3, 2}},
+			{{2, 0}, {2, 1}, {2, 2}, {2, 3}},
+			{{0, 2}, {1, 2}, {2, 2}, {3, 2}},
+			{{2, 0}, {2, 1}, {2, 2}, {2, 3}},
+		}},
+		{"S", []Point{{5, 0}, {6, 0}, {4, 1}, {5, 1}}, [4][]Point{
+			{{1, 1}, {2, 1}, {0, 2}, {1, 2}},
+			{{1, 0}, {1, 1}, {2, 1}, {2, 2}},
+			{{1, 1}, {2, 1}, {0, 2}, {1, 2}},
+			{{1, 0}, {1, 1}, {2, 1}, {2, 2}},
+		}},
+		{"O", []Point{{4, 0}, {5, 0}, {4, 1}, {5, 1}}, [4][]Point{
+			{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+			{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+			{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+			{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+		}},
+	}
+
+	for _, tt := range tests {
+		pieceType, _ := NESTetrominoes.Lookup(tt.name)
+		g := NewGame(NESRules(DefaultConfig(), 0))
+		g.spawnPiece(g.Board.Spawn(pieceType))
+
+		// NES pieces appear in the top rows of the playfield
+		var spawn []Point
+		for _, cell := range g.CurrentPiece.Cells() {
+			spawn = append(spawn, Point{g.CurrentPiece.X + cell.X, g.CurrentPiece.Y + cell.Y - BufferHeight})
+		}
+		if !slices.Equal(spawn, tt.spawn) {
+			t.Errorf("%s spawned at %v, want %v", tt.name, spawn, tt.spawn)
+		}
+
+		piece := NewPiece(pieceType)
+		for turn, want := range tt.turns {
+			if got := piece.Cells(); !slices.Equal(got, want) {
+				t.Errorf("%s after %d turns = %v, want %v", tt.name, turn, got, want)
+			}
+			piece.Rotate(true)
+		}
+	}
+}
+
+func TestNESMarathonGoal(t *testing.T) {
+	config := NESRules(MarathonConfig(150, LevelGoalFixed), 9)
+	if config.FinalLevel != 0 || config.LineGoal != 150 {
+		t.Errorf("NES marathon final level %d, line goal %d, want the 150 line goal", config.FinalLevel, config.LineGoal)
+	}
+	if config := NESRules(MarathonConfig(0, LevelGoalFixed), 9); config.LineGoal != 0 {
+		t.Errorf("endless NES marathon has a %d line goal", config.LineGoal)
+	}
+}
+
+func TestLockOnGravity(t *testing.T) {
+	g := NewGame(NESRules(DefaultConfig(), 0))
+	g.CurrentPiece.Y += g.Board.DropDistance(g.CurrentPiece)
+	g.gravityAcc = 0
+
+	// The grounded piece locks when gravity next pulls it, 48 frames later
+	// on level 0, and no sooner
+	stepFrames(g, 47)
+	if g.Pieces != 0 {
+		t.Fatal("piece locked within 47 frames on the ground")
+	}
+	g.Step()
+	if g.Pieces != 1 {
+		t.Error("piece did not lock when gravity pulled it against the floor")
+	}
+}
+
+func TestNESSoftDrop(t *testing.T) {
+	tests := []struct {
+		level  int
+		frames int
+		want   int // Rows fallen, counting the one on the press
+	}{
+		{0, 16, 9},
+		{19, 16, 9},
+		{29, 10, 11}, // Gravity faster than the soft drop is kept
+	}
+
+	for _, tt := range tests {
+		g := NewGame(NESRules(DefaultConfig(), 0))
+		g.Level = tt.level
+		g.updateGravity()
+		y := g.CurrentPiece.Y
+		g.Press(ActionSoftDrop)
+		stepFrames(g, tt.frames)
+		if fallen := g.CurrentPiece.Y - y; fallen != tt.want {
+			t.Errorf("level %d: soft drop fell %d rows in %d frames, want %d", tt.level, fallen, tt.frames, tt.want)
+		}
+	}
+}
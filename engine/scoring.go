@@ -0,0 +1,159 @@
+package engine
+
+// Scorer turns what happened when a piece locked into points. Each mode
+// picks its scoring rules through Config.Scoring.
+type Scorer interface {
+	// Score returns the points awarded for the lock, including any points
+	// for the rows the piece was soft or hard dropped.
+	Score(result LockResult) int
+}
+
+// ScoringKind selects one of the built-in scoring rule sets
+type ScoringKind int
+
+const (
+	ScoringGuideline    ScoringKind = iota // Modern guideline: T-spins, combos, back-to-back, perfect clears
+	ScoringNES                             // NES: 40/100/300/1200 x (level+1) and soft drop rows
+	ScoringTetrisWorlds                    // Tetris Worlds: plain line clears and drop points
+)
+
+// NewScorer creates the scoring rules of the given kind.
+func NewScorer(kind ScoringKind) Scorer {
+	switch kind {
+	case ScoringNES:
+		return NESScorer{}
+	case ScoringTetrisWorlds:
+		return TetrisWorldsScorer{}
+	default:
+		return GuidelineScorer{}
+	}
+}
+
+// Scoring constants
+const (
+	// Points per row for soft and hard drops
+	scoreSoftDrop = 1
+	scoreHardDrop = 2
+
+	// Bonus per combo step, and the multiplier for back-to-back difficult
+	// clears
+	scoreCombo           = 50
+	backToBackMultiplier = 1.5
+
+	// Normal line clear scores
+	scoreSingle = 100
+	scoreDouble = 300
+	scoreTriple = 500
+	scoreTetris = 800
+
+	// T-spin scores, indexed by lines cleared
+	scoreTSpin       = 400
+	scoreTSpinSingle = 800
+	scoreTSpinDouble = 1200
+	scoreTSpinTriple = 1600
+
+	scoreTSpinMini       = 100
+	scoreTSpinMiniSingle = 200
+	scoreTSpinMiniDouble = 400
+
+	// Perfect clear scores
+	scorePerfectSingle    = 800
+	scorePerfectDouble    = 1200
+	scorePerfectTriple    = 1800
+	scorePerfectTetris    = 2000
+	scorePerfectTetrisB2B = 3200
+)
+
+var lineScores = []int{0, scoreSingle, scoreDouble, scoreTriple, scoreTetris}
+var tSpinScores = []int{scoreTSpin, scoreTSpinSingle, scoreTSpinDouble, scoreTSpinTriple}
+var tSpinMiniScores = []int{scoreTSpinMini, scoreTSpinMiniSingle, scoreTSpinMiniDouble}
+
+// nesLineScores are the NES points per lines cleared, multiplied by level+1
+var nesLineScores = []int{0, 40, 100, 300, 1200}
+
+// GuidelineScorer scores T-spins, combos, back-to-back chains and perfect
+// clears, and awards 1 point per soft-dropped row and 2 per hard-dropped row.
+type GuidelineScorer struct{}
+
+func (GuidelineScorer) Score(result LockResult) int {
+	score := lineClearScore(result.Lines, result.Spin, result.PerfectClear, result.BackToBack)
+
+	// The perfect clear table has its own back-to-back Tetris value
+	if result.BackToBack && !result.PerfectClear {
+		score = int(float64(score) * backToBackMultiplier)
+	}
+	if result.Combo > 0 {
+		score += scoreCombo * result.Combo
+	}
+	return score*result.Level + dropScore(result)
+}
+
+// NESScorer scores line clears as the NES did, 40/100/300/1200 x (level+1),
+// plus a point for every row soft dropped. There are no spin or chain
+// bonuses, and hard drops earn nothing.
+type NESScorer struct{}
+
+func (NESScorer) Score(result LockResult) int {
+	score := result.SoftDropped * scoreSoftDrop
+	if result.Lines < len(nesLineScores) {
+		score += nesLineScores[result.Lines] * (result.Level + 1)
+	}
+	return score
+}
+
+// TetrisWorldsScorer scores line clears 100/300/500/800 x level with soft
+// and hard drop points, but no spin, combo or back-to-back bonuses.
+type TetrisWorldsScorer struct{}
+
+func (TetrisWorldsScorer) Score(result LockResult) int {
+	score := dropScore(result)
+	if result.Lines < len(lineScores) {
+		score += lineScores[result.Lines] * result.Level
+	}
+	return score
+}
+
+// dropScore returns the guideline points for the rows a piece was dropped
+func dropScore(result LockResult) int {
+	return result.SoftDropped*scoreSoftDrop + result.HardDropped*scoreHardDrop
+}
+
+// lineClearScore returns the guideline score (before the level multiplier)
+// for clearing the given number of lines.
+func lineClearScore(linesCleared int, spin SpinType, perfectClear, backToBack bool) int {
+	if perfectClear {
+		// Perfect clear bonuses
+		switch linesCleared {
+		case 1:
+			return scorePerfectSingle
+		case 2:
+			return scorePerfectDouble
+		case 3:
+			return scorePerfectTriple
+		case 4:
+			// Check for back-to-back Tetris
+			if backToBack {
+				return scorePerfectTetrisB2B
+			}
+			return scorePerfectTetris
+		}
+		return 0
+	}
+
+	// T-spins score even when they clear no lines
+	switch spin {
+	case SpinFull:
+		if linesCleared < len(tSpinScores) {
+			return tSpinScores[linesCleared]
+		}
+	case SpinMini:
+		if linesCleared < len(tSpinMiniScores) {
+			return tSpinMiniScores[linesCleared]
+		}
+	}
+
+	if linesCleared < len(lineScores) {
+		return lineScores[linesCleared]
+	}
+	return 0
+}
@@ -0,0 +1,40 @@
+package engine
+
+import "testing"
+
+func TestScorers(t *testing.T) {
+	tetris := LockResult{Lines: 4, Difficult: true, BackToBack: true, Combo: 2, Level: 3, SoftDropped: 5, HardDropped: 10}
+	tsd := LockResult{Lines: 2, Spin: SpinFull, Difficult: true, Combo: -1, Level: 1, HardDropped: 4}
+
+	tests := []struct {
+		kind   ScoringKind
+		result LockResult
+		want   int
+	}{
+		{ScoringGuideline, tetris, (scoreTetris*3/2+2*scoreCombo)*3 + 5 + 20},
+		{ScoringGuideline, tsd, scoreTSpinDouble + 8},
+		{ScoringNES, tetris, 1200*4 + 5},
+		{ScoringNES, tsd, 100 * 2},
+		{ScoringTetrisWorlds, tetris, scoreTetris*3 + 5 + 20},
+		{ScoringTetrisWorlds, tsd, scoreDouble + 8},
+	}
+
+	for _, tt := range tests {
+		if got := NewScorer(tt.kind).Score(tt.result); got != tt.want {
+			t.Errorf("scorer %d scored %+v as %d, want %d", tt.kind, tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestDropPoints(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	g.CurrentPiece = g.Board.Spawn(PieceO)
+	g.Apply(ActionSoftDrop)
+	g.Apply(ActionSoftDrop)
+	rows := boardRows - 2 - g.CurrentPiece.Y
+	g.HardDrop()
+
+	if want := 2*scoreSoftDrop + rows*scoreHardDrop; g.Score != want {
+		t.Errorf("Score = %d, want %d", g.Score, want)
+	}
+}
@@ -0,0 +1,68 @@
+package engine
+
+import "time"
+
+// Snapshot is an immutable copy of the observable game state. It is safe to
+// keep around and inspect after the Game has moved on.
+type Snapshot struct {
+	Seed         int64
+	Board        Board
+	CurrentPiece *Piece
+	Queue        []*Piece
+	HeldPiece    *Piece
+	CanHold      bool
+	Score        int
+	Lines        int
+	Level        int
+	Pieces       int
+	Tetrises     int
+	TSpins       int
+	AwardedLines int
+	GarbageLeft  int
+	GarbageTimer int
+	Combo        int
+	BackToBack   int
+	GameOver     bool
+	GameOverWhy  GameOverReason
+	Paused       bool
+	Phase        Phase
+	ClearingRows []int
+	Elapsed      time.Duration
+}
+
+// Snapshot returns a copy of the current game state.
+func (g *Game) Snapshot() Snapshot {
+	return Snapshot{
+		Seed:         g.Config.Seed,
+		Board:        *g.Board.Clone(),
+		CurrentPiece: g.CurrentPiece.Clone(),
+		Queue:        clonePieces(g.Queue),
+		HeldPiece:    g.HeldPiece.Clone(),
+		CanHold:      g.CanHold,
+		Score:        g.Score,
+		Lines:        g.Lines,
+		Level:        g.Level,
+		Pieces:       g.Pieces,
+		Tetrises:     g.Tetrises,
+		TSpins:       g.TSpins,
+		AwardedLines: g.AwardedLines,
+		GarbageLeft:  g.GarbageLeft,
+		GarbageTimer: g.GarbageTimer,
+		Combo:        g.Combo,
+		BackToBack:   g.BackToBack,
+		GameOver:     g.GameOver,
+		GameOverWhy:  g.GameOverWhy,
+		Paused:       g.Paused,
+		Phase:        g.Phase,
+		ClearingRows: append([]int(nil), g.ClearingRows...),
+		Elapsed:      g.Elapsed(),
+	}
+}
+
+func clonePieces(pieces []*Piece) []*Piece {
+	clones := make([]*Piece, len(pieces))
+	for i, p := range pieces {
+		clones[i] = p.Clone()
+	}
+	return clones
+}
@@ -0,0 +1,78 @@
+package engine
+
+import "time"
+
+// speedCurve contains the Tetris Worlds speed curve G values (rows per frame)
+var speedCurve = []float64{
+	0.01667,  // Level 1
+	0.021017, // Level 2
+	0.026977, // Level 3
+	0.035256, // Level 4
+	0.04693,  // Level 5
+	0.06361,  // Level 6
+	0.0879,   // Level 7
+	0.1236,   // Level 8
+	0.1775,   // Level 9
+	0.2598,   // Level 10
+	0.388,    // Level 11
+	0.59,     // Level 12
+	0.92,     // Level 13
+	1.46,     // Level 14
+	2.36,     // Level 15
+	3.91,     // Level 16
+	6.61,     // Level 17
+	11.43,    // Level 18
+	20.23,    // Level 19
+	36.6,     // Level 20+
+}
+
+// The simulation runs in fixed ticks of one frame
+const (
+	FramesPerSecond = 60
+	FrameDuration   = time.Second / FramesPerSecond
+)
+
+// GravityForLevel returns the speed curve G value (rows per frame) for a level
+func GravityForLevel(level int) float64 {
+	if level < 1 {
+		return speedCurve[0]
+	}
+	if level <= len(speedCurve) {
+		return speedCurve[level-1]
+	}
+	return speedCurve[len(speedCurve)-1] // Default to max speed
+}
+
+// SpeedCurve picks the gravity each level is played at
+type SpeedCurve int
+
+const (
+	SpeedWorlds SpeedCurve = iota // Tetris Worlds curve, from level 1
+	SpeedNES                      // NES (NTSC) frames per row, from level 0
+)
+
+// nesFramesPerRow holds the frames the NES takes to drop a row on levels 0
+// to 28. Level 29 and above drop a row every frame.
+var nesFramesPerRow = []int{
+	48, 43, 38, 33, 28, 23, 18, 13, 8, 6, // Levels 0-9
+	5, 5, 5, // Levels 10-12
+	4, 4, 4, // Levels 13-15
+	3, 3, 3, // Levels 16-18
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2, // Levels 19-28
+}
+
+// nesGravityMargin is added to the NES gravity so that adding 1/n up in
+// floating point reaches a whole row on exactly the nth frame
+const nesGravityMargin = 1e-9
+
+// Gravity returns the G value (rows per frame) of the curve for a level.
+func (c SpeedCurve) Gravity(level int) float64 {
+	if c != SpeedNES {
+		return GravityForLevel(level)
+	}
+	frames := 1
+	if level < len(nesFramesPerRow) {
+		frames = nesFramesPerRow[max(level, 0)]
+	}
+	return 1/float64(frames) + nesGravityMargin
+}
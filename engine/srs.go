@@ -0,0 +1,97 @@
+package engine
+
+// Super Rotation System wall kick data. Offsets are listed exactly as in the
+// guideline tables, with positive Y pointing up; wallKicks flips Y to match
+// the board, where rows grow downwards.
+
+// jlstzKicks holds the kick tests for the J, L, S, T and Z pieces, indexed by
+// the starting orientation and then by direction (0 = clockwise,
+// 1 = counter-clockwise).
+var jlstzKicks = [4][2][5]Point{
+	OrientationSpawn: {
+		{{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}}, // 0->R
+		{{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},    // 0->L
+	},
+	OrientationRight: {
+		{{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}}, // R->2
+		{{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}}, // R->0
+	},
+	OrientationReverse: {
+		{{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},    // 2->L
+		{{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}}, // 2->R
+	},
+	OrientationLeft: {
+		{{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}}, // L->0
+		{{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}}, // L->2
+	},
+}
+
+// iKicks holds the kick tests for the I piece, indexed like jlstzKicks.
+var iKicks = [4][2][5]Point{
+	OrientationSpawn: {
+		{{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}}, // 0->R
+		{{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}}, // 0->L
+	},
+	OrientationRight: {
+		{{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}}, // R->2
+		{{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}}, // R->0
+	},
+	OrientationReverse: {
+		{{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}}, // 2->L
+		{{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}}, // 2->R
+	},
+	OrientationLeft: {
+		{{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}}, // L->0
+		{{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}}, // L->2
+	},
+}
+
+// oKicks is the single in-place test used by the O piece, which never kicks.
+var oKicks = []Point{{0, 0}}
+
+// kickTable holds board-space kick tests indexed like jlstzKicks
+type kickTable [4][2][]Point
+
+// kickTables are the kick tables a piece definition can name. An empty name
+// picks the JLSTZ table.
+var kickTables = map[string]*kickTable{
+	"":      boardKicks(jlstzKicks),
+	"srs":   boardKicks(jlstzKicks),
+	"srs-i": boardKicks(iKicks),
+	"none":  noKicks(),
+}
+
+// boardKicks converts a guideline kick table to board space, where rows grow
+// downwards.
+func boardKicks(table [4][2][5]Point) *kickTable {
+	var kicks kickTable
+	for from := range table {
+		for direction := range table[from] {
+			for _, test := range table[from][direction] {
+				kicks[from][direction] = append(kicks[from][direction], Point{X: test.X, Y: -test.Y})
+			}
+		}
+	}
+	return &kicks
+}
+
+// noKicks returns a table that only tries rotating in place.
+func noKicks() *kickTable {
+	var kicks kickTable
+	for from := range kicks {
+		for direction := range kicks[from] {
+			kicks[from][direction] = oKicks
+		}
+	}
+	return &kicks
+}
+
+// wallKicks returns the board-space offsets to try, in order, when rotating
+// a piece of the given type out of the given orientation.
+func wallKicks(pieceType PieceType, from Orientation, clockwise bool) []Point {
+	direction := 0
+	if !clockwise {
+		direction = 1
+	}
+	return definition(pieceType).kicks[from][direction]
+}
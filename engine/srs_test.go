@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// boardRows is the number of rows on a default board, vanish zone included
+const boardRows = DefaultHeight + BufferHeight
+
+// boardFromRows builds a board whose bottom rows match the given pattern,
+// where '#' is a filled cell and any other character is empty.
+func boardFromRows(rows ...string) *Board {
+	b := NewBoard(DefaultWidth, DefaultHeight)
+	top := boardRows - len(rows)
+	for i, row := range rows {
+		for x, ch := range row {
+			if ch == '#' {
+				b.Fill(x, top+i, garbageColor)
+			}
+		}
+	}
+	return b
+}
+
+func TestOrientationRotate(t *testing.T) {
+	tests := []struct {
+		from      Orientation
+		clockwise bool
+		want      Orientation
+	}{
+		{OrientationSpawn, true, OrientationRight},
+		{OrientationRight, true, OrientationReverse},
+		{OrientationReverse, true, OrientationLeft},
+		{OrientationLeft, true, OrientationSpawn},
+		{OrientationSpawn, false, OrientationLeft},
+		{OrientationLeft, false, OrientationReverse},
+		{OrientationReverse, false, OrientationRight},
+		{OrientationRight, false, OrientationSpawn},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.Rotate(tt.clockwise); got != tt.want {
+			t.Errorf("%d.Rotate(%v) = %d, want %d", tt.from, tt.clockwise, got, tt.want)
+		}
+	}
+}
+
+func TestKickTablesAreSymmetric(t *testing.T) {
+	// Every clockwise transition A->B must be undone by the B->A kicks
+	for _, table := range [][4][2][5]Point{jlstzKicks, iKicks} {
+		for from := OrientationSpawn; from <= OrientationLeft; from++ {
+			to := from.Rotate(true)
+			for i := range 5 {
+				forward := table[from][0][i]
+				back := table[to][1][i]
+				if forward.X != -back.X || forward.Y != -back.Y {
+					t.Errorf("kick %d for %d->%d is %v but reverse is %v", i, from, to, forward, back)
+				}
+			}
+		}
+	}
+}
+
+func TestRotatePiece(t *testing.T) {
+	tests := []struct {
+		name      string
+		board     *Board
+		piece     PieceType
+		start     Orientation
+		x, y      int
+		clockwise bool
+		ok        bool
+		wantRot   Orientation
+		wantX     int
+		wantY     int
+	}{
+		{
+			name:  "T rotates in open space",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceT, start: OrientationSpawn, x: 4, y: 5, clockwise: true,
+			ok: true, wantRot: OrientationRight, wantX: 4, wantY: 5,
+		},
+		{
+			name:  "T kicks off left wall R->2",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceT, start: OrientationRight, x: -1, y: 5, clockwise: true,
+			ok: true, wantRot: OrientationReverse, wantX: 0, wantY: 5,
+		},
+		{
+			name:  "T kicks off right wall L->2",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceT, start: OrientationLeft, x: 8, y: 5, clockwise: false,
+			ok: true, wantRot: OrientationReverse, wantX: 7, wantY: 5,
+		},
+		{
+			name:  "I kicks two left off right wall L->0",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceI, start: OrientationLeft, x: 8, y: 5, clockwise: true,
+			ok: true, wantRot: OrientationSpawn, wantX: 6, wantY: 5,
+		},
+		{
+			name:  "I kicks off left wall R->0",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceI, start: OrientationRight, x: -2, y: 5, clockwise: false,
+			ok: true, wantRot: OrientationSpawn, wantX: 0, wantY: 5,
+		},
+		{
+			name:  "I floor kick 0->R",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceI, start: OrientationSpawn, x: 3, y: boardRows - 2, clockwise: true,
+			ok: true, wantRot: OrientationRight, wantX: 4, wantY: boardRows - 4,
+		},
+		{
+			name:  "O never moves",
+			board: NewBoard(DefaultWidth, DefaultHeight), piece: PieceO, start: OrientationSpawn, x: 8, y: boardRows - 2, clockwise: true,
+			ok: true, wantRot: OrientationRight, wantX: 8, wantY: boardRows - 2,
+		},
+		{
+			name: "T-spin triple 0->R uses fifth kick",
+			board: boardFromRows(
+				"####......",
+				"###.......",
+				"###.######",
+				"###..#####",
+				"###.######",
+			),
+			piece: PieceT, start: OrientationSpawn, x: 3, y: boardRows - 5, clockwise: true,
+			ok: true, wantRot: OrientationRight, wantX: 2, wantY: boardRows - 3,
+		},
+		{
+			name: "T-spin triple 0->L uses fifth kick",
+			board: boardFromRows(
+				"......####",
+				".......###",
+				"######.###",
+				"#####..###",
+				"######.###",
+			),
+			piece: PieceT, start: OrientationSpawn, x: 4, y: boardRows - 5, clockwise: false,
+			ok: true, wantRot: OrientationLeft, wantX: 5, wantY: boardRows - 3,
+		},
+		{
+			name: "blocked rotation is reverted",
+			board: boardFromRows(
+				"#.########",
+				"#.########",
+				"#.########",
+				"#.########",
+			),
+			piece: PieceI, start: OrientationRight, x: -1, y: boardRows - 4, clockwise: true,
+			ok: false, wantRot: OrientationRight, wantX: -1, wantY: boardRows - 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(DefaultConfig())
+			g.Board = tt.board
+
+			piece := NewPiece(tt.piece)
+			for piece.Rotation != tt.start {
+				piece.Rotate(true)
+			}
+			piece.X, piece.Y = tt.x, tt.y
+			if !g.Board.IsValidPosition(piece) {
+				t.Fatalf("start position is not valid")
+			}
+			g.CurrentPiece = piece
+
+			if got := g.RotatePiece(tt.clockwise); got != tt.ok {
+				t.Fatalf("RotatePiece() = %v, want %v", got, tt.ok)
+			}
+			if piece.Rotation != tt.wantRot {
+				t.Errorf("Rotation = %d, want %d", piece.Rotation, tt.wantRot)
+			}
+			if piece.X != tt.wantX || piece.Y != tt.wantY {
+				t.Errorf("position = (%d, %d), want (%d, %d)", piece.X, piece.Y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestTSpinTripleClearsThreeLines(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	g.Board = boardFromRows(
+		"####......",
+		"###.......",
+		"###.######",
+		"###..#####",
+		"###.######",
+	)
+	g.CurrentPiece = NewPiece(PieceT)
+	g.CurrentPiece.X, g.CurrentPiece.Y = 3, boardRows-5
+
+	if !g.RotatePiece(true) {
+		t.Fatal("rotation into T-spin triple slot failed")
+	}
+	g.HardDrop()
+
+	if g.Lines != 3 {
+		t.Errorf("Lines = %d, want 3", g.Lines)
+	}
+	if g.LastLock.Spin != SpinFull || g.LastLock.Points != scoreTSpinTriple {
+		t.Errorf("LastLock = %+v, want a full T-spin triple", g.LastLock)
+	}
+}
+
+func TestOrientationTable(t *testing.T) {
+	piece := NewPiece(PieceT)
+	start := *piece
+
+	piece.Rotate(true)
+	want := []Point{{1, 0}, {1, 1}, {2, 1}, {1, 2}}
+	if got := piece.Cells(); !reflect.DeepEqual(got, want) {
+		t.Errorf("T facing right has cells %v, want %v", got, want)
+	}
+
+	for range 3 {
+		piece.Rotate(true)
+	}
+	if *piece != start {
+		t.Errorf("four clockwise rotations gave %+v, want %+v", *piece, start)
+	}
+}
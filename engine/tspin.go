@@ -0,0 +1,55 @@
+package engine
+
+// SpinType classifies how a piece was spun into place before it locked
+type SpinType int
+
+const (
+	SpinNone SpinType = iota
+	SpinMini          // T-spin mini
+	SpinFull          // T-spin
+)
+
+// finKick is the index of the last SRS kick test. A T-spin reached through
+// it (the T-spin triple and "fin" kicks) always counts as a full T-spin.
+const finKick = 4
+
+// tSpinFrontCorners lists, for each orientation, the two corners of the
+// T's 3x3 box on the side its point faces. The back corners are the others.
+var tSpinFrontCorners = [4][2]Point{
+	OrientationSpawn:   {{0, 0}, {2, 0}},
+	OrientationRight:   {{2, 0}, {2, 2}},
+	OrientationReverse: {{0, 2}, {2, 2}},
+	OrientationLeft:    {{0, 0}, {0, 2}},
+}
+
+// detectTSpin applies the guideline 3-corner rule to the current piece. It
+// is a T-spin when the piece is a T whose last successful move was a
+// rotation and at least three corners of its 3x3 box are filled, with walls
+// and the floor counting as filled. It is a mini unless both front corners
+// are filled or the rotation used the fin kick.
+func (g *Game) detectTSpin() SpinType {
+	piece := g.CurrentPiece
+	if piece.Type != PieceT || !g.lastMoveRotation {
+		return SpinNone
+	}
+
+	filled := 0
+	for _, corner := range []Point{{0, 0}, {2, 0}, {0, 2}, {2, 2}} {
+		if g.Board.isOccupied(piece.X+corner.X, piece.Y+corner.Y) {
+			filled++
+		}
+	}
+	if filled < 3 {
+		return SpinNone
+	}
+
+	front := tSpinFrontCorners[piece.Rotation]
+	if g.lastKick == finKick {
+		return SpinFull
+	}
+	if g.Board.isOccupied(piece.X+front[0].X, piece.Y+front[0].Y) &&
+		g.Board.isOccupied(piece.X+front[1].X, piece.Y+front[1].Y) {
+		return SpinFull
+	}
+	return SpinMini
+}
@@ -0,0 +1,91 @@
+package engine
+
+import "testing"
+
+func TestDetectTSpin(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []string
+		rotation Orientation
+		x        int
+		rotated  bool
+		kick     int
+		want     SpinType
+		lines    int
+		points   int
+	}{
+		{
+			name:     "double",
+			rows:     []string{"...#......", "###...####", "####.#####"},
+			rotation: OrientationReverse, x: 3, rotated: true,
+			want: SpinFull, lines: 2, points: scoreTSpinDouble,
+		},
+		{
+			name:     "mini against the wall",
+			rows:     []string{"..........", "..........", ".#........"},
+			rotation: OrientationRight, x: -1, rotated: true,
+			want: SpinMini, points: scoreTSpinMini,
+		},
+		{
+			name:     "fin kick upgrades mini",
+			rows:     []string{"..........", "..........", ".#........"},
+			rotation: OrientationRight, x: -1, rotated: true, kick: finKick,
+			want: SpinFull, points: scoreTSpin,
+		},
+		{
+			name:     "moved after rotating",
+			rows:     []string{"...#......", "###...####", "####.#####"},
+			rotation: OrientationReverse, x: 3,
+			want: SpinNone, lines: 2, points: scoreDouble,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(DefaultConfig())
+			g.Board = boardFromRows(tt.rows...)
+			g.CurrentPiece = NewPiece(PieceT)
+			for g.CurrentPiece.Rotation != tt.rotation {
+				g.CurrentPiece.Rotate(true)
+			}
+			g.CurrentPiece.X, g.CurrentPiece.Y = tt.x, boardRows-3
+			g.lastMoveRotation = tt.rotated
+			g.lastKick = tt.kick
+
+			g.lockPiece()
+			got := g.LastLock
+			if got.Spin != tt.want || got.Lines != tt.lines || got.Points != tt.points {
+				t.Errorf("LastLock = %+v, want spin %v, %d lines, %d points", got, tt.want, tt.lines, tt.points)
+			}
+		})
+	}
+}
+
+func TestComboAndBackToBack(t *testing.T) {
+	g := NewGame(DefaultConfig())
+	tetris := []string{"#########.", "#########.", "#########.", "#########."}
+
+	// Two Tetrises with an I piece dropped into the well each time
+	for i, want := range []LockResult{
+		{Lines: 4, Difficult: true, Combo: 0, Points: scoreTetris},
+		{Lines: 4, Difficult: true, BackToBack: true, Combo: 1, Points: scoreTetris*3/2 + scoreCombo},
+	} {
+		g.Board = boardFromRows(append([]string{"#........."}, tetris...)...)
+		g.CurrentPiece = NewPiece(PieceI)
+		g.CurrentPiece.Rotate(true)
+		g.CurrentPiece.X, g.CurrentPiece.Y = 7, boardRows-4
+		g.lockPiece()
+
+		got := g.LastLock
+		if got.BackToBack != want.BackToBack || got.Combo != want.Combo || got.Points != want.Points {
+			t.Errorf("lock %d: LastLock = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// A lock without lines ends the combo but keeps the back-to-back chain
+	g.CurrentPiece = NewPiece(PieceO)
+	g.HardDrop()
+	if g.Combo != -1 || g.BackToBack != 1 {
+		t.Errorf("after empty lock Combo = %d, BackToBack = %d; want -1, 1", g.Combo, g.BackToBack)
+	}
+}
@@ -0,0 +1,139 @@
+package engine
+
+import "strings"
+
+// Color represents RGB color values
+type Color [3]float32
+
+// Point represents a 2D coordinate
+type Point struct {
+	X, Y int
+}
+
+// Block represents a single block position
+type Block [2]int
+
+// PieceType identifies a piece definition. The constants are the pieces of
+// the built-in Tetrominoes set; pieces of other sets get their own types
+// when the set is registered.
+type PieceType int
+
+const (
+	PieceI PieceType = iota
+	PieceO
+	PieceT
+	PieceS
+	PieceZ
+	PieceJ
+	PieceL
+)
+
+// Orientation represents the SRS rotation state of a piece
+type Orientation int
+
+const (
+	OrientationSpawn   Orientation = iota // 0
+	OrientationRight                      // R
+	OrientationReverse                    // 2
+	OrientationLeft                       // L
+)
+
+// Rotate returns the orientation reached by rotating once in the given direction
+func (o Orientation) Rotate(clockwise bool) Orientation {
+	if clockwise {
+		return (o + 1) % 4
+	}
+	return (o + 3) % 4
+}
+
+// GameState represents the current state of the game
+type GameState int
+
+const (
+	StateActive GameState = iota
+	StatePaused
+	StateGameOver
+)
+
+// GameOverReason explains why a game ended
+type GameOverReason int
+
+const (
+	GameOverNone     GameOverReason = iota
+	GameOverBlockOut                // A new piece spawned overlapping the stack
+	GameOverLockOut                 // A piece locked entirely inside the vanish zone
+	GameOverTopOut                  // Garbage pushed the stack out of the vanish zone
+	GameOverFinished                // The mode's goal was reached
+	GameOverTimeUp                  // The mode's time limit ran out
+	GameOverSolved                  // The puzzle's objective was achieved
+	GameOverFailed                  // The puzzle's pieces ran out before its objective was achieved
+)
+
+func (r GameOverReason) String() string {
+	switch r {
+	case GameOverBlockOut:
+		return "block out"
+	case GameOverLockOut:
+		return "lock out"
+	case GameOverTopOut:
+		return "top out"
+	case GameOverFinished:
+		return "finished"
+	case GameOverTimeUp:
+		return "time up"
+	case GameOverSolved:
+		return "solved"
+	case GameOverFailed:
+		return "failed"
+	}
+	return ""
+}
+
+// ToppedOut reports whether the game was lost rather than finished
+func (r GameOverReason) ToppedOut() bool {
+	return r == GameOverBlockOut || r == GameOverLockOut || r == GameOverTopOut
+}
+
+// LockResult describes what happened when a piece locked, so scoring and
+// the UI can react to it
+type LockResult struct {
+	Piece        PieceType
+	Lines        int      // Lines cleared
+	Garbage      int      // Garbage rows among the lines cleared
+	Spin         SpinType // T-spin recognised at lock
+	PerfectClear bool     // The clear left the board empty
+	Difficult    bool     // Tetris or T-spin line clear, eligible for back-to-back
+	BackToBack   bool     // The back-to-back bonus applied
+	Combo        int      // Combo count after the lock, -1 when it broke the chain
+	Level        int      // Level the piece locked on
+	SoftDropped  int      // Rows the piece fell while soft drop was held
+	HardDropped  int      // Rows the piece fell in its hard drop
+	Points       int      // Points awarded for the lock
+	Frame        int      // Frame the piece locked on
+}
+
+// String names the clear for announcements, e.g. "T-spin mini single".
+// It is empty for a lock that is not worth announcing.
+func (r LockResult) String() string {
+	lines := [...]string{"", "single", "double", "triple", "tetris"}
+	name := ""
+	if r.Lines < len(lines) {
+		name = lines[r.Lines]
+	}
+
+	switch r.Spin {
+	case SpinFull:
+		name = strings.TrimSpace("T-spin " + name)
+	case SpinMini:
+		name = strings.TrimSpace("T-spin mini " + name)
+	default:
+		if r.Lines < 4 {
+			name = ""
+		}
+	}
+
+	if r.PerfectClear {
+		name = strings.TrimSpace(name + " perfect clear")
+	}
+	return name
+}
@@ -1,52 +1,45 @@
 package main
 
 import (
-	"time"
-	
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/mgomes/go-tetris/engine"
 )
 
 type InputHandler struct {
-	keyStates     map[glfw.Key]bool
-	keyTimers     map[glfw.Key]time.Time
-	keyPressed    map[glfw.Key]bool
-	keyRepeatTimers map[glfw.Key]time.Time
+	keyStates  map[glfw.Key]bool
+	keyPressed map[glfw.Key]bool
+	held       map[engine.Action]bool // Held actions the game has been told about
+	newGame    func() *engine.Game    // Starts the game R switches to
+}
+
+// heldKeys lists the keys whose engine actions repeat while held. The
+// engine runs DAS and soft drop itself, in frames.
+var heldKeys = []struct {
+	key    glfw.Key
+	action engine.Action
+}{
+	{glfw.KeyLeft, engine.ActionMoveLeft},
+	{glfw.KeyRight, engine.ActionMoveRight},
+	{glfw.KeyDown, engine.ActionSoftDrop},
 }
 
 func NewInputHandler() *InputHandler {
 	return &InputHandler{
-		keyStates:     make(map[glfw.Key]bool),
-		keyTimers:     make(map[glfw.Key]time.Time),
-		keyPressed:    make(map[glfw.Key]bool),
-		keyRepeatTimers: make(map[glfw.Key]time.Time),
+		keyStates:  make(map[glfw.Key]bool),
+		keyPressed: make(map[glfw.Key]bool),
+		held:       make(map[engine.Action]bool),
 	}
 }
 
 func (ih *InputHandler) HandleKeyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
 	if action == glfw.Press {
 		ih.keyStates[key] = true
-		ih.keyTimers[key] = time.Now()
 		ih.keyPressed[key] = true
-		delete(ih.keyRepeatTimers, key)
 	} else if action == glfw.Release {
 		ih.keyStates[key] = false
-		delete(ih.keyTimers, key)
-		delete(ih.keyPressed, key)
-		delete(ih.keyRepeatTimers, key)
 	}
 }
 
-func (ih *InputHandler) IsKeyJustPressed(key glfw.Key) bool {
-	if pressed, ok := ih.keyStates[key]; ok && pressed {
-		if timer, ok := ih.keyTimers[key]; ok {
-			if time.Since(timer) < keyJustPressedWindow {
-				return true
-			}
-		}
-	}
-	return false
-}
-
 func (ih *InputHandler) IsKeyPressed(key glfw.Key) bool {
 	return ih.keyPressed[key]
 }
@@ -55,111 +48,137 @@ func (ih *InputHandler) ConsumeKeyPress(key glfw.Key) {
 	delete(ih.keyPressed, key)
 }
 
-func (ih *InputHandler) IsKeyRepeating(key glfw.Key) bool {
-	return ih.isKeyRepeatingWithSpeed(key, keyRepeatDelay, keyRepeatInterval, keyRepeatFastInterval, keyFastThreshold)
-}
-
-func (ih *InputHandler) isKeyRepeatingWithSpeed(key glfw.Key, repeatDelay, repeatInterval, fastInterval, fastThreshold time.Duration) bool {
-	if pressed, ok := ih.keyStates[key]; ok && pressed {
-		if timer, ok := ih.keyTimers[key]; ok {
-			elapsed := time.Since(timer)
-			if elapsed > repeatDelay {
-				interval := repeatInterval
-				if elapsed > fastThreshold {
-					interval = fastInterval
-				}
-				
-				if lastRepeat, ok := ih.keyRepeatTimers[key]; !ok || time.Since(lastRepeat) >= interval {
-					ih.keyRepeatTimers[key] = time.Now()
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
-
-func (ih *InputHandler) ProcessGameInput(game *Game, window *glfw.Window) {
+// ProcessGameInput applies the keys pressed since the last frame and returns
+// the game to carry on with, which is a new one after a restart.
+func (ih *InputHandler) ProcessGameInput(game *engine.Game, window *glfw.Window) *engine.Game {
 	// System controls
 	if ih.IsKeyPressed(glfw.KeyEscape) {
 		window.SetShouldClose(true)
 		ih.ConsumeKeyPress(glfw.KeyEscape)
-		return
+		return game
+	}
+
+	if browser != nil {
+		var browsing bool
+		if game, browsing = ih.processPuzzleInput(game); browsing {
+			return game
+		}
 	}
 
 	if ih.IsKeyPressed(glfw.KeyP) {
-		game.Paused = !game.Paused
+		game.Apply(engine.ActionPause)
 		ih.ConsumeKeyPress(glfw.KeyP)
 	}
 
-	// Game over controls
+	// R restarts at any time, retrying a puzzle
+	if ih.IsKeyPressed(glfw.KeyR) {
+		ih.ConsumeKeyPress(glfw.KeyR)
+		return ih.restart(game)
+	}
 	if game.GameOver {
-		if ih.IsKeyPressed(glfw.KeyR) {
-			*game = *NewGame()
-			ih.ConsumeKeyPress(glfw.KeyR)
-		}
-		return
+		return game
 	}
 
+	// Movement controls
+	ih.processMovementInput(game)
+
 	if game.Paused {
-		return
+		return game
 	}
 
-	// Movement controls
-	ih.processMovementInput(game)
-	
 	// Rotation controls
 	ih.processRotationInput(game)
-	
+
 	// Special action controls
 	ih.processActionInput(game)
+	return game
 }
 
-func (ih *InputHandler) processMovementInput(game *Game) {
-	if ih.IsKeyPressed(glfw.KeyLeft) {
-		game.MovePiece(-1, 0)
-		ih.ConsumeKeyPress(glfw.KeyLeft)
-	} else if ih.IsKeyRepeating(glfw.KeyLeft) {
-		game.MovePiece(-1, 0)
+// restart starts a new game in place of the current one, forgetting the
+// held keys. Every game shares the pausable game clock, so a paused game is
+// resumed first or the new one would start frozen.
+func (ih *InputHandler) restart(game *engine.Game) *engine.Game {
+	if game.Paused {
+		game.Apply(engine.ActionPause)
 	}
-	
-	if ih.IsKeyPressed(glfw.KeyRight) {
-		game.MovePiece(1, 0)
-		ih.ConsumeKeyPress(glfw.KeyRight)
-	} else if ih.IsKeyRepeating(glfw.KeyRight) {
-		game.MovePiece(1, 0)
+	clear(ih.held)
+	return ih.newGame()
+}
+
+// processPuzzleInput handles the puzzle browser, and the keys that skip to
+// the next puzzle or go back to the browser during play. It returns the
+// game to carry on with and reports whether the browser took the input.
+func (ih *InputHandler) processPuzzleInput(game *engine.Game) (*engine.Game, bool) {
+	if !browser.open {
+		switch {
+		case ih.IsKeyPressed(glfw.KeyN):
+			ih.ConsumeKeyPress(glfw.KeyN)
+			browser.move(1)
+			browser.choose()
+			game = ih.restart(game)
+		case ih.IsKeyPressed(glfw.KeyB):
+			browser.open = true
+			ih.ConsumeKeyPress(glfw.KeyB)
+		}
+		return game, browser.open
+	}
+
+	switch {
+	case ih.IsKeyPressed(glfw.KeyUp):
+		browser.move(-1)
+	case ih.IsKeyPressed(glfw.KeyDown):
+		browser.move(1)
+	case ih.IsKeyPressed(glfw.KeyEnter):
+		browser.choose()
+		game = ih.restart(game)
 	}
 	
-	if ih.IsKeyPressed(glfw.KeyDown) {
-		game.MovePiece(0, 1)
-		ih.ConsumeKeyPress(glfw.KeyDown)
-	} else if ih.IsKeyRepeating(glfw.KeyDown) {
-		game.MovePiece(0, 1)
+	// Keys pressed in the browser must not carry over into the game
+	clear(ih.keyPressed)
+	return game, true
+}
+
+// processMovementInput tells the game when movement keys go down and up.
+// A tap shorter than a frame still registers as a press followed by a
+// release.
+func (ih *InputHandler) processMovementInput(game *engine.Game) {
+	for _, held := range heldKeys {
+		key, action := held.key, held.action
+		if ih.IsKeyPressed(key) {
+			game.Press(action)
+			ih.held[action] = true
+			ih.ConsumeKeyPress(key)
+		}
+
+		if ih.held[action] && !ih.keyStates[key] {
+			game.Release(action)
+			delete(ih.held, action)
+		}
 	}
 }
 
-func (ih *InputHandler) processRotationInput(game *Game) {
+func (ih *InputHandler) processRotationInput(game *engine.Game) {
 	if ih.IsKeyPressed(glfw.KeyUp) {
-		game.RotatePiece(true)
+		game.Apply(engine.ActionRotateCW)
 		ih.ConsumeKeyPress(glfw.KeyUp)
 	}
-	
+
 	if ih.IsKeyPressed(glfw.KeyLeftShift) || ih.IsKeyPressed(glfw.KeyRightShift) {
-		game.RotatePiece(false)
+		game.Apply(engine.ActionRotateCCW)
 		ih.ConsumeKeyPress(glfw.KeyLeftShift)
 		ih.ConsumeKeyPress(glfw.KeyRightShift)
 	}
 }
 
-func (ih *InputHandler) processActionInput(game *Game) {
+func (ih *InputHandler) processActionInput(game *engine.Game) {
 	if ih.IsKeyPressed(glfw.KeySpace) {
-		game.HardDrop()
+		game.Apply(engine.ActionHardDrop)
 		ih.ConsumeKeyPress(glfw.KeySpace)
 	}
-	
+
 	if ih.IsKeyPressed(glfw.KeyLeftControl) || ih.IsKeyPressed(glfw.KeyRightControl) {
-		game.HoldPiece()
+		game.Apply(engine.ActionHold)
 		ih.ConsumeKeyPress(glfw.KeyLeftControl)
 		ih.ConsumeKeyPress(glfw.KeyRightControl)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package main
+
+// layout places the board and the HUD for a board size. The window stays
+// the same size, so large boards get smaller cells and narrow boards pull
+// the HUD in beside them.
+type layout struct {
+	boardWidth  int // Visible columns
+	boardHeight int // Visible rows
+	cellSize    int
+	panelX      int // Left edge of the hold and info box column
+	nextX       int // Left edge of the preview queue column
+	announceY   int // Baseline of the clear announcement under the board
+}
+
+func newLayout(width, height int) layout {
+	cell := min(maxCellSize, maxBoardPixelWidth/width, maxBoardPixelHeight/height)
+	panelX := boardOffsetX + width*cell + 50
+
+	return layout{
+		boardWidth:  width,
+		boardHeight: height,
+		cellSize:    cell,
+		panelX:      panelX,
+		nextX:       panelX + infoBoxWidth + 30,
+		announceY:   boardOffsetY + height*cell + 40,
+	}
+}
+
+// boardPixelWidth and boardPixelHeight return the size of the visible board
+// on screen.
+func (l layout) boardPixelWidth() int {
+	return l.boardWidth * l.cellSize
+}
+
+func (l layout) boardPixelHeight() int {
+	return l.boardHeight * l.cellSize
+}
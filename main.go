@@ -1,24 +1,184 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"os"
 	"runtime"
 	"time"
 
 	"github.com/go-gl/gl/v2.1/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/mgomes/go-tetris/engine"
 )
 
 // Window constants are now in constants.go
 
 var inputHandler *InputHandler
 
+// gameClock is the game timer shared by every game. It stops while the game
+// is paused, so pausing never lets simulation time pile up.
+var gameClock = engine.NewPausableClock(engine.NewRealClock())
+
+// Command-line options
+var (
+	seedFlag        = flag.Int64("seed", 0, "game seed; 0 picks a new random seed for every game")
+	randomizerFlag  = flag.String("randomizer", "bag7", "piece generator: bag7, bag14, history, nes or uniform")
+	scoringFlag     = flag.String("scoring", "guideline", "scoring rules: guideline, nes or worlds")
+	previewsFlag    = flag.Int("previews", 5, "number of upcoming pieces shown, 0 to 7")
+	widthFlag       = flag.Int("width", engine.DefaultWidth, "board width in columns, 4 to 32")
+	heightFlag      = flag.Int("height", engine.DefaultHeight, "visible board height in rows, 4 to 60")
+	piecesFlag      = flag.String("pieces", "tetrominoes", "piece set: tetrominoes, triominoes, pentominoes or a JSON piece set file")
+	modeFlag        = flag.String("mode", "marathon", "game mode: marathon, sprint, ultra, dig, survival or puzzle")
+	linesFlag       = flag.Int("lines", 40, "lines to clear in sprint mode: 20, 40 or 100")
+	timeFlag        = flag.Duration("time", engine.DefaultUltraTime, "time limit in ultra mode")
+	goalFlag        = flag.Int("goal", 150, "lines in a marathon: 150 or 200")
+	endlessFlag     = flag.Bool("endless", false, "play marathon without a goal until the stack tops out")
+	variableFlag    = flag.Bool("variable", false, "level up marathon with the guideline variable goal")
+	garbageFlag     = flag.Int("garbage", 10, "garbage rows to clear in dig mode")
+	garbageKindFlag = flag.String("garbage-kind", "cheese", "dig garbage: cheese, messy or random")
+	messinessFlag   = flag.Float64("messiness", 0.3, "chance a messy garbage hole moves, 0 to 1")
+	puzzlesFlag     = flag.String("puzzles", "basics", "puzzle pack: basics or a JSON puzzle pack file")
+	rulesFlag       = flag.String("rules", "guideline", "ruleset: guideline or nes")
+	levelFlag       = flag.Int("level", 0, "starting level with -rules nes, 0 to 19")
+)
+
+// randomizers maps the -randomizer flag values to piece generators
+var randomizers = map[string]engine.RandomizerKind{
+	"bag7":    engine.RandomizerBag7,
+	"bag14":   engine.RandomizerBag14,
+	"history": engine.RandomizerHistory,
+	"nes":     engine.RandomizerNES,
+	"uniform": engine.RandomizerUniform,
+}
+
+// pieceSets maps the built-in -pieces flag values to piece sets; any other
+// value is read as a piece set file
+var pieceSets = map[string]*engine.PieceSet{
+	"tetrominoes": engine.Tetrominoes,
+	"triominoes":  engine.Triominoes,
+	"pentominoes": engine.Pentominoes,
+}
+
+// pieceSet is the piece set chosen with -pieces
+var pieceSet *engine.PieceSet
+
+// puzzlePacks maps the built-in -puzzles flag values to puzzle packs; any
+// other value is read as a puzzle pack file
+var puzzlePacks = map[string]*engine.PuzzlePack{
+	"basics": engine.BasicPuzzles,
+}
+
+// scorings maps the -scoring flag values to scoring rules
+var scorings = map[string]engine.ScoringKind{
+	"guideline": engine.ScoringGuideline,
+	"nes":       engine.ScoringNES,
+	"worlds":    engine.ScoringTetrisWorlds,
+}
+
 func init() {
 	runtime.LockOSThread()
 }
 
+// newGameConfig builds the engine configuration from the command line. A
+// fixed -seed replays the same game on every restart.
+func newGameConfig() engine.Config {
+	config := playMode.config()
+	config.Randomizer = randomizers[*randomizerFlag]
+	config.Scoring = scorings[*scoringFlag]
+	config.Previews = *previewsFlag
+	config.Width = *widthFlag
+	config.Height = *heightFlag
+	config.PieceSet = pieceSet
+	config.Clock = gameClock
+	if *rulesFlag == "nes" {
+		config = engine.NESRules(config, *levelFlag)
+	}
+
+	config.Seed = *seedFlag
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	return config
+}
+
 func main() {
+	flag.Parse()
+	if _, ok := randomizers[*randomizerFlag]; !ok {
+		log.Fatalln("unknown randomizer:", *randomizerFlag)
+	}
+	if _, ok := scorings[*scoringFlag]; !ok {
+		log.Fatalln("unknown scoring:", *scoringFlag)
+	}
+	newMode, ok := modes[*modeFlag]
+	if !ok {
+		log.Fatalln("unknown mode:", *modeFlag)
+	}
+	if *widthFlag < engine.MinWidth || *widthFlag > engine.MaxWidth {
+		log.Fatalln("board width must be", engine.MinWidth, "to", engine.MaxWidth, "columns, not", *widthFlag)
+	}
+	if *heightFlag < engine.MinHeight || *heightFlag > engine.MaxHeight {
+		log.Fatalln("board height must be", engine.MinHeight, "to", engine.MaxHeight, "rows, not", *heightFlag)
+	}
+	if !validSprintGoal(*linesFlag) {
+		log.Fatalln("sprint lines must be 20, 40 or 100, not", *linesFlag)
+	}
+	if !validMarathonGoal(*goalFlag) {
+		log.Fatalln("marathon goal must be 150 or 200 lines, not", *goalFlag)
+	}
+	if *modeFlag == "dig" && (*garbageFlag < 1 || *garbageFlag >= *heightFlag) {
+		log.Fatalln("dig garbage must be 1 to", *heightFlag-1, "rows, not", *garbageFlag)
+	}
+	if _, ok := garbageKinds[*garbageKindFlag]; !ok {
+		log.Fatalln("unknown garbage kind:", *garbageKindFlag)
+	}
+	if *messinessFlag < 0 || *messinessFlag > 1 {
+		log.Fatalln("messiness must be between 0 and 1, not", *messinessFlag)
+	}
+	if *timeFlag <= 0 {
+		log.Fatalln("ultra time limit must be positive, not", *timeFlag)
+	}
+	switch *rulesFlag {
+	case "guideline":
+	case "nes":
+		if *modeFlag == "puzzle" {
+			log.Fatalln("puzzles are played with the guideline rules")
+		}
+		if *variableFlag {
+			log.Fatalln("NES levels always take 10 lines, -variable does not apply")
+		}
+		if *levelFlag < 0 || *levelFlag > engine.NESMaxStartLevel {
+			log.Fatalln("starting level must be 0 to", engine.NESMaxStartLevel, "not", *levelFlag)
+		}
+	default:
+		log.Fatalln("unknown ruleset:", *rulesFlag)
+	}
+	if *modeFlag == "puzzle" {
+		pack := puzzlePacks[*puzzlesFlag]
+		if pack == nil {
+			var err error
+			if pack, err = loadPuzzlePack(*puzzlesFlag); err != nil {
+				log.Fatalln("failed to load puzzle pack:", err)
+			}
+		}
+		browser = &puzzleBrowser{pack: pack, open: true}
+	}
+	playMode = newMode()
+	if *rulesFlag == "nes" {
+		playMode.name += fmt.Sprintf("-nes-%d", *levelFlag)
+	}
+	bests = loadRecords()
+
+	pieceSet = pieceSets[*piecesFlag]
+	if pieceSet == nil {
+		set, err := loadPieceSet(*piecesFlag)
+		if err != nil {
+			log.Fatalln("failed to load piece set:", err)
+		}
+		pieceSet = set
+	}
+
 	if err := glfw.Init(); err != nil {
 		log.Fatalln("failed to initialize glfw:", err)
 	}
@@ -45,33 +205,90 @@ func main() {
 	version := gl.GoStr(gl.GetString(gl.VERSION))
 	fmt.Println("OpenGL version", version)
 
-	game := NewGame()
 	renderer := NewRenderer(windowWidth, windowHeight)
 	renderer.SetupProjection()
+	
+	// Every game, including restarts, reports its events to the renderer
+	// and its result to the personal bests
+	inputHandler.newGame = func() *engine.Game {
+		game := engine.NewGame(newGameConfig())
+		renderer.Watch(game)
+		game.Subscribe(func(e engine.Event) {
+			switch e.(type) {
+			case engine.TopOut, engine.Finished:
+				renderer.newBest = bests.submit(playMode, game)
+			}
+		})
+		return game
+	}
+	game := inputHandler.newGame()
 
-	lastFrame := time.Now()
+	// The engine advances in fixed 60 Hz frames. Game time is accumulated
+	// and spent in whole frames so rendering speed never affects gameplay.
+	lastTime := gameClock.Now()
+	var accumulator time.Duration
 
 	for !window.ShouldClose() {
-		currentFrame := time.Now()
-		deltaTime := currentFrame.Sub(lastFrame)
-		lastFrame = currentFrame
+		frameStart := time.Now()
+
+		now := gameClock.Now()
+		accumulator += now - lastTime
+		lastTime = now
+		if accumulator > maxFrameLag {
+			accumulator = maxFrameLag
+		}
 
-		inputHandler.ProcessGameInput(game, window)
-		game.Update()
+		game = inputHandler.ProcessGameInput(game, window)
+		browsing := browser != nil && browser.open
+		if browsing {
+			// Nothing is played while a puzzle is being chosen
+			accumulator = 0
+		}
+		for accumulator >= engine.FrameDuration {
+			game.Step()
+			accumulator -= engine.FrameDuration
+		}
 
 		renderer.Clear()
-		renderer.DrawBoard(game.Board)
-		renderer.DrawGhostPiece(game)
-		renderer.DrawPiece(game.CurrentPiece)
-		renderer.DrawHeldPiece(game.HeldPiece)
-		renderer.DrawUI(game)
+		if browsing {
+			renderer.DrawPuzzleBrowser(browser)
+		} else {
+			renderer.DrawBoard(game.Board)
+			if playMode.heading != nil {
+				renderer.DrawHeading(playMode.heading())
+			}
+			renderer.DrawLineClear(game)
+			renderer.DrawGarbageMeter(game)
+			if game.Phase.Active() {
+				if !game.Config.NoGhost {
+					renderer.DrawGhostPiece(game)
+				}
+				renderer.DrawPiece(game.CurrentPiece)
+			}
+			if !game.Config.NoHold {
+				renderer.DrawHeldPiece(game.HeldPiece)
+			}
+			renderer.DrawUI(game, playMode.stats(game))
+			if game.GameOver {
+				renderer.DrawResults(game, playMode.results(game), playMode.restart)
+			}
+		}
 
 		window.SwapBuffers()
 		glfw.PollEvents()
 
-		if deltaTime < frameTargetTime {
-			time.Sleep(frameTargetTime - deltaTime)
+		if elapsed := time.Since(frameStart); elapsed < frameTargetTime {
+			time.Sleep(frameTargetTime - elapsed)
 		}
 	}
 }
 
+// loadPieceSet reads a piece set description from a JSON file.
+func loadPieceSet(path string) (*engine.PieceSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return engine.LoadPieceSet(f)
+}
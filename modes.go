@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/mgomes/go-tetris/engine"
+)
+
+// stat is a labelled value in one of the info boxes beside the board
+type stat struct {
+	label string
+	value string // Drawn with 7-segment digits
+	color [3]float32
+}
+
+// Info box colors
+var (
+	neonGreen  = [3]float32{0.0, 1.0, 0.5}
+	neonOrange = [3]float32{1.0, 0.5, 0.0}
+	neonCyan   = [3]float32{0.0, 1.0, 1.0}
+	neonYellow = [3]float32{1.0, 1.0, 0.0}
+	neonPink   = [3]float32{1.0, 0.0, 0.5}
+)
+
+// record describes a mode's personal best
+type record struct {
+	lowerWins bool                                  // Lower values are better, as for times
+	value     func(game *engine.Game) (int64, bool) // The game's result, if it counts
+	format    func(value int64) string
+}
+
+// mode is a way to play: the engine rules it starts from, the stats shown
+// beside the board and the personal best it keeps.
+type mode struct {
+	name    string // Names the mode's personal best
+	config  func() engine.Config
+	stats   func(game *engine.Game) []stat // Info boxes from the top
+	results func(game *engine.Game) []stat // Shown when the game ends
+	heading func() string                  // Shown above the board; nil for none
+	restart string                         // Controls shown when the game ends; empty for the usual restart
+	record  record
+}
+
+// modes maps the -mode flag values to game modes
+var modes = map[string]func() *mode{
+	"marathon": marathonMode,
+	"sprint":   sprintMode,
+	"ultra":    ultraMode,
+	"dig":      digMode,
+	"survival": survivalMode,
+	"puzzle":   puzzleMode,
+}
+
+// garbageKinds maps the -garbage-kind flag values to kinds of garbage
+var garbageKinds = map[string]engine.GarbageKind{
+	"cheese": engine.GarbageCheese,
+	"messy":  engine.GarbageMessy,
+	"random": engine.GarbageRandom,
+}
+
+// playMode is the mode chosen with -mode
+var playMode *mode
+
+// marathonMode plays through the levels of a -goal line Marathon, or
+// endlessly with -endless, keeping the best score.
+func marathonMode() *mode {
+	lines, goal, name := *goalFlag, engine.LevelGoalFixed, fmt.Sprintf("marathon-%d", *goalFlag)
+	if *endlessFlag {
+		lines, name = 0, "marathon-endless"
+	}
+	if *variableFlag {
+		goal, name = engine.LevelGoalVariable, name+"-variable"
+	}
+
+	return &mode{
+		name: name,
+		config: func() engine.Config {
+			return engine.MarathonConfig(lines, goal)
+		},
+		stats: func(game *engine.Game) []stat {
+			return []stat{
+				{"SCORE", strconv.Itoa(game.Score), neonGreen},
+				{"LEVEL", strconv.Itoa(game.Level), neonOrange},
+				{"GOAL", strconv.Itoa(game.LinesToNextLevel()), neonOrange},
+				{"TIME", formatGameTime(game.Elapsed()), neonCyan},
+				{"COMBO", strconv.Itoa(max(game.Combo, 0)), neonYellow},
+				{"B2B", strconv.Itoa(max(game.BackToBack, 0)), neonPink},
+			}
+		},
+		results: func(game *engine.Game) []stat {
+			return []stat{
+				{"SCORE", strconv.Itoa(game.Score), neonGreen},
+				{"LINES", strconv.Itoa(game.Lines), neonCyan},
+				{"LEVEL", strconv.Itoa(game.Level), neonOrange},
+				{"TIME", formatGameTime(game.Elapsed()), neonCyan},
+			}
+		},
+		record: record{
+			value: func(game *engine.Game) (int64, bool) {
+				return int64(game.Score), true
+			},
+			format: formatScore,
+		},
+	}
+}
+
+// sprintMode races to clear the -lines target, keeping the fastest time.
+func sprintMode() *mode {
+	goal := *linesFlag
+	m := &mode{
+		name: fmt.Sprintf("sprint-%d", goal),
+		config: func() engine.Config {
+			return engine.SprintConfig(goal)
+		},
+		results: func(game *engine.Game) []stat {
+			return []stat{
+				{"TIME", formatPreciseTime(game.Elapsed()), neonCyan},
+				{"PIECES", strconv.Itoa(game.Pieces), neonGreen},
+				{"PPS", fmt.Sprintf("%.2f", game.PiecesPerSecond()), neonYellow},
+			}
+		},
+		record: record{
+			lowerWins: true,
+			value: func(game *engine.Game) (int64, bool) {
+				return game.Elapsed().Milliseconds(), game.GameOverWhy == engine.GameOverFinished
+			},
+			format: formatRecordTime,
+		},
+	}
+	m.stats = func(game *engine.Game) []stat {
+		return []stat{
+			{"TIME", formatPreciseTime(game.Elapsed()), neonCyan},
+			{"LINES", strconv.Itoa(max(goal-game.Lines, 0)), neonOrange},
+			{"PIECES", strconv.Itoa(game.Pieces), neonGreen},
+			{"PPS", fmt.Sprintf("%.2f", game.PiecesPerSecond()), neonYellow},
+			{"BEST", bests.format(m), neonPink},
+		}
+	}
+	return m
+}
+
+// ultraMode scores as much as possible before the -time limit runs out,
+// keeping the best score for each time limit.
+func ultraMode() *mode {
+	limit := *timeFlag
+	m := &mode{
+		name: fmt.Sprintf("ultra-%s", limit),
+		config: func() engine.Config {
+			return engine.UltraConfig(limit)
+		},
+		results: func(game *engine.Game) []stat {
+			return []stat{
+				{"SCORE", strconv.Itoa(game.Score), neonGreen},
+				{"LINES", strconv.Itoa(game.Lines), neonCyan},
+				{"TETRIS", strconv.Itoa(game.Tetrises), neonOrange},
+				{"T-SPIN", strconv.Itoa(game.TSpins), neonPink},
+			}
+		},
+		record: record{
+			value: func(game *engine.Game) (int64, bool) {
+				return int64(game.Score), game.GameOverWhy == engine.GameOverTimeUp
+			},
+			format: formatScore,
+		},
+	}
+	m.stats = func(game *engine.Game) []stat {
+		return []stat{
+			{"TIME", formatGameTime(game.Remaining()), neonCyan},
+			{"SCORE", strconv.Itoa(game.Score), neonGreen},
+			{"LINES", strconv.Itoa(game.Lines), neonOrange},
+			{"TETRIS", strconv.Itoa(game.Tetrises), neonYellow},
+			{"BEST", bests.format(m), neonPink},
+		}
+	}
+	return m
+}
+
+// digMode races to clear the -garbage rows the board starts with, keeping
+// the fastest time for each amount and kind of garbage.
+func digMode() *mode {
+	rows, kind, messiness := *garbageFlag, garbageKinds[*garbageKindFlag], *messinessFlag
+	name := fmt.Sprintf("dig-%d-%s", rows, *garbageKindFlag)
+	if kind == engine.GarbageMessy {
+		name += fmt.Sprintf("-%g", messiness)
+	}
+
+	m := &mode{
+		name: name,
+		config: func() engine.Config {
+			return engine.DigConfig(rows, kind, messiness)
+		},
+		results: func(game *engine.Game) []stat {
+			return []stat{
+				{"TIME", formatPreciseTime(game.Elapsed()), neonCyan},
+				{"PIECES", strconv.Itoa(game.Pieces), neonGreen},
+				{"PPS", fmt.Sprintf("%.2f", game.PiecesPerSecond()), neonYellow},
+			}
+		},
+		record: record{
+			lowerWins: true,
+			value: func(game *engine.Game) (int64, bool) {
+				return game.Elapsed().Milliseconds(), game.GameOverWhy == engine.GameOverFinished
+			},
+			format: formatRecordTime,
+		},
+	}
+	m.stats = func(game *engine.Game) []stat {
+		return []stat{
+			{"TIME", formatPreciseTime(game.Elapsed()), neonCyan},
+			{"GARBAGE", strconv.Itoa(game.GarbageLeft), neonOrange},
+			{"PIECES", strconv.Itoa(game.Pieces), neonGreen},
+			{"PPS", fmt.Sprintf("%.2f", game.PiecesPerSecond()), neonYellow},
+			{"BEST", bests.format(m), neonPink},
+		}
+	}
+	return m
+}
+
+// survivalMode lasts as long as the stack holds out against rising
+// garbage, keeping the longest survival time.
+func survivalMode() *mode {
+	m := &mode{
+		name:   "survival",
+		config: engine.SurvivalConfig,
+		results: func(game *engine.Game) []stat {
+			return []stat{
+				{"TIME", formatGameTime(game.Elapsed()), neonCyan},
+				{"LINES", strconv.Itoa(game.Lines), neonGreen},
+				{"LEVEL", strconv.Itoa(game.Level), neonOrange},
+			}
+		},
+		record: record{
+			value: func(game *engine.Game) (int64, bool) {
+				return game.Elapsed().Milliseconds(), true
+			},
+			format: formatRecordTime,
+		},
+	}
+	m.stats = func(game *engine.Game) []stat {
+		return []stat{
+			{"TIME", formatGameTime(game.Elapsed()), neonCyan},
+			{"LINES", strconv.Itoa(game.Lines), neonGreen},
+			{"LEVEL", strconv.Itoa(game.Level), neonOrange},
+			{"SCORE", strconv.Itoa(game.Score), neonYellow},
+			{"BEST", bests.format(m), neonPink},
+		}
+	}
+	return m
+}
+
+// validSprintGoal reports whether lines is one of the Sprint targets
+func validSprintGoal(lines int) bool {
+	return slices.Contains(engine.SprintGoals, lines)
+}
+
+// validMarathonGoal reports whether lines is one of the Marathon lengths
+func validMarathonGoal(lines int) bool {
+	return slices.Contains(engine.MarathonGoals, lines)
+}
+
+func formatScore(score int64) string {
+	return strconv.FormatInt(score, 10)
+}
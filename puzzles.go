@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mgomes/go-tetris/engine"
+)
+
+// puzzleBrowser lists the puzzles of the -puzzles pack and keeps track of
+// the one being played
+type puzzleBrowser struct {
+	pack     *engine.PuzzlePack
+	selected int  // Index of the highlighted or current puzzle
+	open     bool // The list is shown instead of the game
+}
+
+// browser is the puzzle pack browser, nil outside puzzle mode
+var browser *puzzleBrowser
+
+// puzzle returns the selected puzzle
+func (b *puzzleBrowser) puzzle() *engine.Puzzle {
+	return b.pack.Puzzles[b.selected]
+}
+
+// move highlights the puzzle by steps up or down the list, wrapping around
+func (b *puzzleBrowser) move(by int) {
+	n := len(b.pack.Puzzles)
+	b.selected = ((b.selected+by)%n + n) % n
+}
+
+// choose makes the selected puzzle the one played and names the mode's
+// personal best after it
+func (b *puzzleBrowser) choose() {
+	b.open = false
+	playMode.name = b.recordName(b.selected)
+}
+
+// recordName names the personal best for the puzzle at index i
+func (b *puzzleBrowser) recordName(i int) string {
+	return fmt.Sprintf("puzzle-%s-%s", b.pack.Name, b.pack.Puzzles[i].Name)
+}
+
+// best returns the personal best for the puzzle at index i, or "-"
+// without one
+func (b *puzzleBrowser) best(i int) string {
+	best, ok := bests.best[b.recordName(i)]
+	if !ok {
+		return "-"
+	}
+	return playMode.record.format(best)
+}
+
+// puzzleMode plays the puzzles of the -puzzles pack, chosen from the
+// browser, keeping the fewest pieces each was solved with.
+func puzzleMode() *mode {
+	m := &mode{
+		config: func() engine.Config {
+			return engine.PuzzleConfig(browser.puzzle())
+		},
+		results: func(game *engine.Game) []stat {
+			return []stat{
+				{"PUZZLE", strconv.Itoa(browser.selected + 1), neonCyan},
+				{"PIECES", strconv.Itoa(game.Pieces), neonGreen},
+				{"LINES", strconv.Itoa(game.Lines), neonOrange},
+			}
+		},
+		heading: func() string {
+			p := browser.puzzle()
+			return strings.ToUpper(p.Name + " - " + p.Goal())
+		},
+		restart: "R RETRY   N NEXT   B PUZZLES",
+		record: record{
+			lowerWins: true,
+			value: func(game *engine.Game) (int64, bool) {
+				return int64(game.Pieces), game.GameOverWhy == engine.GameOverSolved
+			},
+			format: formatScore,
+		},
+	}
+	m.stats = func(game *engine.Game) []stat {
+		p := browser.puzzle()
+		stats := []stat{
+			{"PUZZLE", strconv.Itoa(browser.selected + 1), neonCyan},
+			{"PIECES", strconv.Itoa(max(p.Pieces-game.Pieces, 0)), neonGreen},
+		}
+		if p.Objective == engine.ObjectiveLines {
+			stats = append(stats, stat{"LINES", strconv.Itoa(max(p.Lines-game.Lines, 0)), neonOrange})
+		}
+		return append(stats, stat{"BEST", bests.format(m), neonPink})
+	}
+	return m
+}
+
+// loadPuzzlePack reads a puzzle pack from a JSON file.
+func loadPuzzlePack(path string) (*engine.PuzzlePack, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return engine.LoadPuzzlePack(f)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mgomes/go-tetris/engine"
+)
+
+// records holds the personal best of every mode. They are kept in a JSON
+// file in the user's config directory.
+type records struct {
+	path string
+	best map[string]int64
+}
+
+// bests are the personal bests loaded at startup
+var bests *records
+
+func loadRecords() *records {
+	r := &records{best: make(map[string]int64)}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		log.Println("personal bests will not be saved:", err)
+		return r
+	}
+	r.path = filepath.Join(dir, "go-tetris", "records.json")
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &r.best)
+	}
+	if err != nil {
+		log.Println("failed to read personal bests:", err)
+	}
+	return r
+}
+
+// submit records the result of a finished game, reporting whether it is a
+// new personal best for the mode.
+func (r *records) submit(m *mode, game *engine.Game) bool {
+	value, ok := m.record.value(game)
+	if !ok {
+		return false
+	}
+
+	if best, ok := r.best[m.name]; ok {
+		if m.record.lowerWins && value >= best || !m.record.lowerWins && value <= best {
+			return false
+		}
+	}
+	r.best[m.name] = value
+	r.save()
+	return true
+}
+
+func (r *records) save() {
+	if r.path == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(r.best, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(r.path, data, 0o644)
+	}
+	if err != nil {
+		log.Println("failed to save personal bests:", err)
+	}
+}
+
+// format returns the mode's personal best for display, or "-" without one
+func (r *records) format(m *mode) string {
+	best, ok := r.best[m.name]
+	if !ok {
+		return "-"
+	}
+	return m.record.format(best)
+}
+
+// formatRecordTime formats a time record kept in milliseconds
+func formatRecordTime(ms int64) string {
+	return formatPreciseTime(time.Duration(ms) * time.Millisecond)
+}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 	
 	"github.com/go-gl/gl/v2.1/gl"
+	"github.com/mgomes/go-tetris/engine"
 )
 
 // Rendering constants are now in constants.go
@@ -11,12 +15,36 @@ import (
 type Renderer struct {
 	windowWidth  int
 	windowHeight int
+	layout       layout // Board and HUD placement for the current board size
+	announcement string // Name of the last notable clear
+	announcedAt  int    // Frame the announcement was made
+	newBest      bool   // The finished game set a personal best
 }
 
 func NewRenderer(width, height int) *Renderer {
 	return &Renderer{
 		windowWidth:  width,
 		windowHeight: height,
+		layout:       newLayout(engine.DefaultWidth, engine.DefaultHeight),
+	}
+}
+
+// Watch subscribes the renderer to a new game's events, forgetting anything
+// announced in the previous game.
+func (r *Renderer) Watch(game *engine.Game) {
+	r.announcement = ""
+	r.newBest = false
+	game.Subscribe(r.handleEvent)
+}
+
+func (r *Renderer) handleEvent(e engine.Event) {
+	switch e := e.(type) {
+	case engine.Locked:
+		// T-spins, Tetrises and perfect clears are announced
+		if name := e.Result.String(); name != "" {
+			r.announcement = strings.ToUpper(name)
+			r.announcedAt = e.Result.Frame
+		}
 	}
 }
 
@@ -29,58 +57,115 @@ func (r *Renderer) Clear() {
 	r.drawSynthwaveGrid()
 }
 
-func (r *Renderer) DrawBoard(board *Board) {
+// DrawBoard draws the visible playfield. It lays the screen out for the
+// board's size, so it must be drawn before the pieces and the HUD.
+func (r *Renderer) DrawBoard(board *engine.Board) {
+	r.layout = newLayout(board.Width, board.Height)
 	r.drawBorder()
 	
-	for y := range boardHeight {
-		for x := range boardWidth {
-			if board.Grid[y][x] {
-				color := board.Colors[y][x]
+	for y := range board.Height {
+		row := y + engine.BufferHeight
+		for x := range board.Width {
+			if board.Filled(x, row) {
+				color := board.Colors[row][x]
 				r.drawBlock(x, y, color[0], color[1], color[2])
 			}
 		}
 	}
 }
 
-func (r *Renderer) DrawPiece(piece *Piece) {
-	blocks := piece.GetBlocks()
-	for _, block := range blocks {
-		x, y := block[0], block[1]
-		if y >= 0 {
-			r.drawBlock(x, y, piece.Color[0], piece.Color[1], piece.Color[2])
+// DrawLineClear flashes the rows being cleared, fading them out over the
+// line clear delay.
+func (r *Renderer) DrawLineClear(game *engine.Game) {
+	if game.Phase != engine.PhaseLineClear || game.Config.LineClearDelay <= 0 {
+		return
+	}
+	
+	alpha := float32(game.PhaseTimer) / float32(game.Config.LineClearDelay)
+	cellSize := float32(r.layout.cellSize)
+	width := float32(r.layout.boardPixelWidth())
+	
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.Color4f(1.0, 1.0, 1.0, alpha)
+	gl.Begin(gl.QUADS)
+	for _, row := range game.ClearingRows {
+		y := row - engine.BufferHeight
+		if y < 0 {
+			continue
 		}
+		pixelY := float32(boardOffsetY) + float32(y)*cellSize
+		gl.Vertex2f(boardOffsetX, pixelY)
+		gl.Vertex2f(boardOffsetX+width, pixelY)
+		gl.Vertex2f(boardOffsetX+width, pixelY+cellSize)
+		gl.Vertex2f(boardOffsetX, pixelY+cellSize)
 	}
+	gl.End()
+	gl.Disable(gl.BLEND)
 }
 
-func (r *Renderer) DrawGhostPiece(game *Game) {
-	if game.Paused {
+// DrawGarbageMeter fills a bar beside the board as the next rising garbage
+// row gets closer, turning from yellow to red.
+func (r *Renderer) DrawGarbageMeter(game *engine.Game) {
+	if !game.Config.RisingGarbage || !game.Phase.Started() {
 		return
 	}
 	
-	ghost := &Piece{
-		Shape:    game.CurrentPiece.Shape,
-		Color:    game.CurrentPiece.Color,
-		X:        game.CurrentPiece.X,
-		Y:        game.CurrentPiece.Y,
-		Rotation: game.CurrentPiece.Rotation,
-	}
+	fill := 1 - float32(game.GarbageTimer)/float32(game.RiseInterval())
+	fill = min(max(fill, 0), 1)
+	height := float32(r.layout.boardPixelHeight())
+	bottom := float32(boardOffsetY) + height
+	left := float32(boardOffsetX - garbageMeterGap - garbageMeterWidth)
+	right := left + garbageMeterWidth
 	
-	for game.Board.IsValidPosition(ghost) {
-		ghost.Y++
+	gl.Color3f(1.0, 1.0-fill, 0.0)
+	gl.Begin(gl.QUADS)
+	gl.Vertex2f(left, bottom-height*fill)
+	gl.Vertex2f(right, bottom-height*fill)
+	gl.Vertex2f(right, bottom)
+	gl.Vertex2f(left, bottom)
+	gl.End()
+	
+	// Outline of the empty meter
+	gl.LineWidth(1.0)
+	gl.Color3f(1.0, 0.0, 1.0)
+	gl.Begin(gl.LINE_LOOP)
+	gl.Vertex2f(left, float32(boardOffsetY))
+	gl.Vertex2f(right, float32(boardOffsetY))
+	gl.Vertex2f(right, bottom)
+	gl.Vertex2f(left, bottom)
+	gl.End()
+}
+
+func (r *Renderer) DrawPiece(piece *engine.Piece) {
+	color := piece.Color()
+	for _, cell := range piece.Cells() {
+		x, y := piece.X+cell.X, piece.Y+cell.Y-engine.BufferHeight
+		if y >= 0 {
+			r.drawBlock(x, y, color[0], color[1], color[2])
+		}
+	}
+}
+
+func (r *Renderer) DrawGhostPiece(game *engine.Game) {
+	if game.Paused {
+		return
 	}
-	ghost.Y--
 	
-	blocks := ghost.GetBlocks()
-	for _, block := range blocks {
-		x, y := block[0], block[1]
+	ghost := *game.CurrentPiece
+	ghost.Y += game.Board.DropDistance(&ghost)
+	
+	color := ghost.Color()
+	for _, cell := range ghost.Cells() {
+		x, y := ghost.X+cell.X, ghost.Y+cell.Y-engine.BufferHeight
 		if y >= 0 {
-			r.drawBlock(x, y, ghost.Color[0]*0.3, ghost.Color[1]*0.3, ghost.Color[2]*0.3)
+			r.drawBlock(x, y, color[0]*0.3, color[1]*0.3, color[2]*0.3)
 		}
 	}
 }
 
-func (r *Renderer) DrawHeldPiece(piece *Piece) {
-	holdX := holdBoxX
+func (r *Renderer) DrawHeldPiece(piece *engine.Piece) {
+	holdX := r.layout.panelX
 	holdY := holdBoxY
 	
 	// Draw "HOLD" label above the box
@@ -101,90 +186,89 @@ func (r *Renderer) DrawHeldPiece(piece *Piece) {
 		return
 	}
 	
-	// Draw the held piece (scaled down) with simple blocks
-	for y, row := range piece.Shape {
-		for x, filled := range row {
-			if filled {
-				pixelX := float32(holdX + x*miniBlockSize)
-				pixelY := float32(holdY + y*miniBlockSize)
-				
-				// Simple flat blocks for UI
-				gl.Color3f(piece.Color[0]*0.8, piece.Color[1]*0.8, piece.Color[2]*0.8)
-				gl.Begin(gl.QUADS)
-				gl.Vertex2f(pixelX, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY+18)
-				gl.Vertex2f(pixelX, pixelY+18)
-				gl.End()
-				
-				// Outline
-				gl.Color3f(piece.Color[0], piece.Color[1], piece.Color[2])
-				gl.Begin(gl.LINE_LOOP)
-				gl.Vertex2f(pixelX, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY+18)
-				gl.Vertex2f(pixelX, pixelY+18)
-				gl.End()
-			}
-		}
+	r.drawMiniPiece(holdX, holdY, piece)
+}
+
+// drawMiniPiece draws a piece scaled down for the hold and next boxes.
+// Pieces with boxes wider than four cells are shrunk to fit.
+func (r *Renderer) drawMiniPiece(originX, originY int, piece *engine.Piece) {
+	block := min(miniBlockSize, 4*miniBlockSize/piece.Size())
+	size := float32(block - 2)
+	color := piece.Color()
+	for _, cell := range piece.Cells() {
+		pixelX := float32(originX + cell.X*block)
+		pixelY := float32(originY + cell.Y*block)
+		
+		// Simple flat blocks for UI
+		gl.Color3f(color[0]*0.8, color[1]*0.8, color[2]*0.8)
+		gl.Begin(gl.QUADS)
+		gl.Vertex2f(pixelX, pixelY)
+		gl.Vertex2f(pixelX+size, pixelY)
+		gl.Vertex2f(pixelX+size, pixelY+size)
+		gl.Vertex2f(pixelX, pixelY+size)
+		gl.End()
+		
+		// Outline
+		gl.Color3f(color[0], color[1], color[2])
+		gl.Begin(gl.LINE_LOOP)
+		gl.Vertex2f(pixelX, pixelY)
+		gl.Vertex2f(pixelX+size, pixelY)
+		gl.Vertex2f(pixelX+size, pixelY+size)
+		gl.Vertex2f(pixelX, pixelY+size)
+		gl.End()
 	}
 }
 
-func (r *Renderer) DrawUI(game *Game) {
-	// Draw score box
-	r.drawLabel(scoreBoxX+10, scoreBoxY-25, "SCORE", 0.0, 1.0, 0.5)
-	r.drawInfoBox(scoreBoxX, scoreBoxY, infoBoxWidth, infoBoxHeight, 0.0, 1.0, 0.5) // Neon green
-	r.drawNumber(scoreBoxX+10, scoreBoxY+20, game.Score, 0.0, 1.0, 0.5)
-	
-	// Draw level box
-	r.drawLabel(levelBoxX+10, levelBoxY-25, "LEVEL", 1.0, 0.5, 0.0)
-	r.drawInfoBox(levelBoxX, levelBoxY, infoBoxWidth, infoBoxHeight, 1.0, 0.5, 0.0) // Orange
-	r.drawNumber(levelBoxX+10, levelBoxY+20, game.Level, 1.0, 0.5, 0.0)
-	
-	// Draw next piece preview
-	nextX := nextBoxX
-	nextY := nextBoxY
+// DrawUI draws the mode's stats in a column of info boxes, the preview
+// queue and any overlays.
+func (r *Renderer) DrawUI(game *engine.Game, stats []stat) {
+	for i, st := range stats {
+		y := statBoxY + i*statBoxSpacing
+		red, green, blue := st.color[0], st.color[1], st.color[2]
+		r.drawLabel(r.layout.panelX+10, y-25, st.label, red, green, blue)
+		r.drawInfoBox(r.layout.panelX, y, infoBoxWidth, infoBoxHeight, red, green, blue)
+		r.drawDigits(r.layout.panelX+10, y+20, st.value, red, green, blue)
+	}
 	
-	// Draw "NEXT" label
-	r.drawLabel(nextX+10, nextY-25, "NEXT", 1.0, 0.0, 1.0)
+	// Draw the preview queue as a vertical stack sized to the number of pieces
+	if len(game.Queue) > 0 {
+		nextX := r.layout.nextX
+		nextY := nextBoxY
+		bottom := nextY + len(game.Queue)*nextSlotSize - miniBlockSize + 10
+		
+		// Draw "NEXT" label
+		r.drawLabel(nextX+10, nextY-25, "NEXT", 1.0, 0.0, 1.0)
+		
+		// Draw neon magenta next box border
+		gl.LineWidth(2.0)
+		gl.Color3f(1.0, 0.0, 1.0)
+		gl.Begin(gl.LINE_LOOP)
+		gl.Vertex2f(float32(nextX-10), float32(nextY-10))
+		gl.Vertex2f(float32(nextX+4*miniBlockSize+10), float32(nextY-10))
+		gl.Vertex2f(float32(nextX+4*miniBlockSize+10), float32(bottom))
+		gl.Vertex2f(float32(nextX-10), float32(bottom))
+		gl.End()
+		gl.LineWidth(1.0)
+		
+		for i, piece := range game.Queue {
+			r.drawMiniPiece(nextX, nextY+i*nextSlotSize, piece)
+		}
+	}
 	
-	// Draw neon magenta next box border
-	gl.LineWidth(2.0)
-	gl.Color3f(1.0, 0.0, 1.0)
-	gl.Begin(gl.LINE_LOOP)
-	gl.Vertex2f(float32(nextX-10), float32(nextY-10))
-	gl.Vertex2f(float32(nextX+4*miniBlockSize+10), float32(nextY-10))
-	gl.Vertex2f(float32(nextX+4*miniBlockSize+10), float32(nextY+4*miniBlockSize+10))
-	gl.Vertex2f(float32(nextX-10), float32(nextY+4*miniBlockSize+10))
-	gl.End()
-	gl.LineWidth(1.0)
+	// Announce T-spins, Tetrises and perfect clears for a moment under the board
+	if r.announcement != "" && game.Frame-r.announcedAt < announceFrames {
+		r.drawCenteredText(boardOffsetX+r.layout.boardPixelWidth()/2, r.layout.announceY, r.announcement, 1.0, 0.0, 0.8)
+	}
 	
-	// Draw next piece (scaled down)
-	for y, row := range game.NextPiece.Shape {
-		for x, filled := range row {
-			if filled {
-				pixelX := float32(nextX + x*miniBlockSize)
-				pixelY := float32(nextY + y*miniBlockSize)
-				
-				// Simple flat blocks for UI
-				gl.Color3f(game.NextPiece.Color[0]*0.8, game.NextPiece.Color[1]*0.8, game.NextPiece.Color[2]*0.8)
-				gl.Begin(gl.QUADS)
-				gl.Vertex2f(pixelX, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY+18)
-				gl.Vertex2f(pixelX, pixelY+18)
-				gl.End()
-				
-				// Outline
-				gl.Color3f(game.NextPiece.Color[0], game.NextPiece.Color[1], game.NextPiece.Color[2])
-				gl.Begin(gl.LINE_LOOP)
-				gl.Vertex2f(pixelX, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY)
-				gl.Vertex2f(pixelX+18, pixelY+18)
-				gl.Vertex2f(pixelX, pixelY+18)
-				gl.End()
-			}
-		}
+	// Count down over the board, then wait for the first input
+	centerX := boardOffsetX + r.layout.boardPixelWidth()/2
+	centerY := boardOffsetY + r.layout.boardPixelHeight()/2
+	switch game.Phase {
+	case engine.PhaseCountdown:
+		seconds := (game.PhaseTimer + engine.FramesPerSecond - 1) / engine.FramesPerSecond
+		r.drawCenteredDigits(centerX, centerY, strconv.Itoa(seconds), 1.0, 0.0, 1.0)
+	case engine.PhaseReady:
+		r.drawCenteredText(centerX, centerY, "READY", 1.0, 0.0, 1.0)
 	}
 	
 	// Draw pause overlay if paused
@@ -201,14 +285,12 @@ func (r *Renderer) DrawUI(game *Game) {
 		gl.End()
 		gl.Disable(gl.BLEND)
 	}
-	
-	// Draw game over overlay
-	if game.GameOver {
-		r.drawGameOverBanner(game)
-	}
 }
 
-func (r *Renderer) drawGameOverBanner(game *Game) {
+// DrawResults shows the game over banner: why the game ended, the mode's
+// results side by side and the controls to carry on, or the usual restart
+// when controls is empty.
+func (r *Renderer) DrawResults(game *engine.Game, results []stat, controls string) {
 	// Semi-transparent dark overlay
 	gl.Enable(gl.BLEND)
 	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
@@ -221,8 +303,8 @@ func (r *Renderer) drawGameOverBanner(game *Game) {
 	gl.End()
 	
 	// Banner background
-	bannerY := float32(r.windowHeight/2 - 100)
-	bannerHeight := float32(200)
+	bannerY := float32(r.windowHeight/2 - 130)
+	bannerHeight := float32(260)
 	
 	// Gradient banner with neon glow
 	gl.Begin(gl.QUADS)
@@ -256,20 +338,72 @@ func (r *Renderer) drawGameOverBanner(game *Game) {
 	gl.Vertex2f(float32(r.windowWidth), bannerY+bannerHeight-5)
 	gl.End()
 	
-	// "GAME OVER" text (stylized with lines)
-	r.drawGameOverText(r.windowWidth/2, int(bannerY+50))
+	// "GAME OVER" text (stylized with lines) when the player topped out
+	if game.GameOverWhy.ToppedOut() {
+		r.drawGameOverText(r.windowWidth/2, int(bannerY+40))
+	}
+	
+	// Why the game ended
+	why := strings.ToUpper(game.GameOverWhy.String())
+	if r.newBest {
+		why += " - NEW BEST"
+	}
+	r.drawCenteredText(r.windowWidth/2, int(bannerY+80), why, 1.0, 0.5, 0.0)
+	
+	// Results display
+	scoreY := int(bannerY + 110)
+	for i, result := range results {
+		centerX := r.windowWidth * (i + 1) / (len(results) + 1)
+		red, green, blue := result.color[0], result.color[1], result.color[2]
+		r.drawCenteredText(centerX, scoreY, result.label, red, green, blue)
+		r.drawCenteredDigits(centerX, scoreY+20, result.value, red, green, blue)
+	}
 	
-	// Score display
-	scoreY := int(bannerY + 120)
-	r.drawCenteredText(r.windowWidth/2, scoreY, "SCORE", 0.0, 1.0, 0.5)
-	r.drawCenteredNumber(r.windowWidth/2, scoreY+25, game.Score, 0.0, 1.0, 0.5)
+	// Seed display so the game can be replayed with -seed
+	seedY := scoreY + 60
+	r.drawCenteredText(r.windowWidth/2, seedY, "SEED", 0.0, 1.0, 1.0)
+	r.drawCenteredNumber(r.windowWidth/2, seedY+20, int(game.Config.Seed), 0.0, 1.0, 1.0)
 	
 	// Instructions
-	r.drawCenteredText(r.windowWidth/2, scoreY+60, "PRESS R TO RESTART", 1.0, 0.0, 0.8)
+	if controls == "" {
+		controls = "PRESS R TO RESTART"
+	}
+	r.drawCenteredText(r.windowWidth/2, seedY+65, controls, 1.0, 0.0, 0.8)
 	
 	gl.Disable(gl.BLEND)
 }
 
+// DrawHeading writes text centred above the board, such as the puzzle
+// being played.
+func (r *Renderer) DrawHeading(text string) {
+	r.drawCenteredText(boardOffsetX+r.layout.boardPixelWidth()/2, boardOffsetY-30, text, 0.0, 1.0, 1.0)
+}
+
+// DrawPuzzleBrowser lists the puzzles of a pack with their personal bests,
+// scrolled to keep the highlighted puzzle in view.
+func (r *Renderer) DrawPuzzleBrowser(b *puzzleBrowser) {
+	r.drawCenteredText(r.windowWidth/2, browserTop-60, strings.ToUpper(b.pack.Name), 1.0, 0.0, 1.0)
+	
+	first := min(max(b.selected-browserRows/2, 0), max(len(b.pack.Puzzles)-browserRows, 0))
+	last := min(first+browserRows, len(b.pack.Puzzles))
+	for i := first; i < last; i++ {
+		y := browserTop + (i-first)*browserRowHeight
+		red, green, blue := float32(0.0), float32(1.0), float32(1.0)
+		if i == b.selected {
+			red, green, blue = 1.0, 0.0, 0.8
+			r.drawInfoBox(browserLeft-20, y-12, r.windowWidth-2*(browserLeft-20), browserRowHeight-6, red, green, blue)
+		}
+		
+		p := b.pack.Puzzles[i]
+		r.drawDigits(browserLeft, y-2, strconv.Itoa(i+1), red, green, blue)
+		r.drawLabel(browserLeft+50, y, strings.ToUpper(p.Name), red, green, blue)
+		r.drawLabel(browserLeft+50, y+14, strings.ToUpper(p.Goal()), 0.6, 0.6, 0.8)
+		r.drawDigits(r.windowWidth-browserLeft-60, y-2, b.best(i), 0.0, 1.0, 0.5)
+	}
+	
+	r.drawCenteredText(r.windowWidth/2, r.windowHeight-60, "UP DOWN SELECT   ENTER PLAY   ESC QUIT", 1.0, 0.5, 0.0)
+}
+
 func (r *Renderer) drawGameOverText(centerX, y int) {
 	// Large stylized "GAME OVER" using lines
 	gl.LineWidth(3.0)
@@ -375,23 +509,32 @@ func (r *Renderer) drawGameOverText(centerX, y int) {
 }
 
 func (r *Renderer) drawCenteredText(centerX, y int, text string, red, green, blue float32) {
-	// Simple centered text - just draw a line for now (would need proper text rendering)
-	gl.Color3f(red, green, blue)
-	// This is simplified - in a real implementation you'd render actual text
+	// Labels advance 12 pixels per letter, the last letter is 8 pixels wide
+	totalWidth := len(text)*12 - 4
+	r.drawLabel(centerX-totalWidth/2, y, text, red, green, blue)
 }
 
 func (r *Renderer) drawCenteredNumber(centerX, y int, number int, red, green, blue float32) {
-	digits := fmt.Sprintf("%d", number)
-	digitWidth := 15
-	totalWidth := len(digits) * digitWidth
-	startX := centerX - totalWidth/2
+	r.drawCenteredDigits(centerX, y, fmt.Sprintf("%d", number), red, green, blue)
+}
+
+func (r *Renderer) drawCenteredDigits(centerX, y int, digits string, red, green, blue float32) {
+	totalWidth := 0
+	for _, digit := range digits {
+		if digit == ':' || digit == '.' {
+			totalWidth += punctuationWidth
+		} else {
+			totalWidth += digitWidth
+		}
+	}
 	
-	r.drawNumber(startX, y, number, red, green, blue)
+	r.drawDigits(centerX-totalWidth/2, y, digits, red, green, blue)
 }
 
 func (r *Renderer) drawBlock(x, y int, red, green, blue float32) {
-	pixelX := float32(boardOffsetX + x*cellSize)
-	pixelY := float32(boardOffsetY + y*cellSize)
+	cellSize := float32(r.layout.cellSize)
+	pixelX := float32(boardOffsetX) + float32(x)*cellSize
+	pixelY := float32(boardOffsetY) + float32(y)*cellSize
 	depth := float32(4) // 3D depth offset
 	
 	// Draw back face (darker)
@@ -461,9 +604,9 @@ func (r *Renderer) drawBorder() {
 	gl.Color3f(1.0, 0.0, 0.8)
 	gl.Begin(gl.LINE_LOOP)
 	gl.Vertex2f(float32(boardOffsetX-5), float32(boardOffsetY-5))
-	gl.Vertex2f(float32(boardOffsetX+boardWidth*cellSize+5), float32(boardOffsetY-5))
-	gl.Vertex2f(float32(boardOffsetX+boardWidth*cellSize+5), float32(boardOffsetY+boardHeight*cellSize+5))
-	gl.Vertex2f(float32(boardOffsetX-5), float32(boardOffsetY+boardHeight*cellSize+5))
+	gl.Vertex2f(float32(boardOffsetX+r.layout.boardPixelWidth()+5), float32(boardOffsetY-5))
+	gl.Vertex2f(float32(boardOffsetX+r.layout.boardPixelWidth()+5), float32(boardOffsetY+r.layout.boardPixelHeight()+5))
+	gl.Vertex2f(float32(boardOffsetX-5), float32(boardOffsetY+r.layout.boardPixelHeight()+5))
 	gl.End()
 	
 	// Inner glow
@@ -471,9 +614,9 @@ func (r *Renderer) drawBorder() {
 	gl.Color3f(1.0, 0.3, 0.9)
 	gl.Begin(gl.LINE_LOOP)
 	gl.Vertex2f(float32(boardOffsetX-3), float32(boardOffsetY-3))
-	gl.Vertex2f(float32(boardOffsetX+boardWidth*cellSize+3), float32(boardOffsetY-3))
-	gl.Vertex2f(float32(boardOffsetX+boardWidth*cellSize+3), float32(boardOffsetY+boardHeight*cellSize+3))
-	gl.Vertex2f(float32(boardOffsetX-3), float32(boardOffsetY+boardHeight*cellSize+3))
+	gl.Vertex2f(float32(boardOffsetX+r.layout.boardPixelWidth()+3), float32(boardOffsetY-3))
+	gl.Vertex2f(float32(boardOffsetX+r.layout.boardPixelWidth()+3), float32(boardOffsetY+r.layout.boardPixelHeight()+3))
+	gl.Vertex2f(float32(boardOffsetX-3), float32(boardOffsetY+r.layout.boardPixelHeight()+3))
 	gl.End()
 }
 
@@ -533,17 +676,35 @@ func (r *Renderer) drawInfoBox(x, y, width, height int, red, green, blue float32
 }
 
 func (r *Renderer) drawNumber(x, y int, number int, red, green, blue float32) {
+	r.drawDigits(x, y, fmt.Sprintf("%d", number), red, green, blue)
+}
+
+func (r *Renderer) drawDigits(x, y int, digits string, red, green, blue float32) {
 	// Simple 7-segment style number rendering
-	digits := fmt.Sprintf("%d", number)
-	digitWidth := 15
 	digitX := x
 	
 	for _, digit := range digits {
 		r.drawDigit(digitX, y, digit, red, green, blue)
-		digitX += digitWidth
+		if digit == ':' || digit == '.' {
+			digitX += punctuationWidth
+		} else {
+			digitX += digitWidth
+		}
 	}
 }
 
+// formatGameTime formats a game time as M:SS.cc
+func formatGameTime(d time.Duration) string {
+	centiseconds := int(d / (10 * time.Millisecond))
+	return fmt.Sprintf("%d:%02d.%02d", centiseconds/6000, centiseconds/100%60, centiseconds%100)
+}
+
+// formatPreciseTime formats a game time to the millisecond as M:SS.mmm
+func formatPreciseTime(d time.Duration) string {
+	ms := int(d / time.Millisecond)
+	return fmt.Sprintf("%d:%02d.%03d", ms/60000, ms/1000%60, ms%1000)
+}
+
 func (r *Renderer) drawDigit(x, y int, digit rune, red, green, blue float32) {
 	gl.Color3f(red, green, blue)
 	gl.LineWidth(2.0)
@@ -627,6 +788,23 @@ func (r *Renderer) drawDigit(x, y int, digit rune, red, green, blue float32) {
 		gl.Vertex2f(float32(x+2), float32(y+10))
 		gl.Vertex2f(float32(x+10), float32(y+10))
 		gl.End()
+	case ':':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+2), float32(y+6))
+		gl.Vertex2f(float32(x+2), float32(y+8))
+		gl.Vertex2f(float32(x+2), float32(y+13))
+		gl.Vertex2f(float32(x+2), float32(y+15))
+		gl.End()
+	case '.':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+2), float32(y+16))
+		gl.Vertex2f(float32(x+2), float32(y+18))
+		gl.End()
+	case '-':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+2), float32(y+10))
+		gl.Vertex2f(float32(x+10), float32(y+10))
+		gl.End()
 	case '9':
 		gl.Begin(gl.LINE_STRIP)
 		gl.Vertex2f(float32(x+2), float32(y+18))
@@ -754,6 +932,224 @@ func (r *Renderer) drawSmallLetter(x, y int, letter rune) {
 		gl.Vertex2f(float32(x+4), float32(y+10))
 		gl.Vertex2f(float32(x+8), float32(y))
 		gl.End()
+	case 'A':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+4), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+2), float32(y+6))
+		gl.Vertex2f(float32(x+6), float32(y+6))
+		gl.End()
+	case 'B':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+6), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+2))
+		gl.Vertex2f(float32(x+6), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+8))
+		gl.Vertex2f(float32(x+6), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+6), float32(y+5))
+		gl.End()
+	case 'F':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+6), float32(y+5))
+		gl.End()
+	case 'G':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.Vertex2f(float32(x+4), float32(y+5))
+		gl.End()
+	case 'I':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+1), float32(y))
+		gl.Vertex2f(float32(x+7), float32(y))
+		gl.Vertex2f(float32(x+4), float32(y))
+		gl.Vertex2f(float32(x+4), float32(y+10))
+		gl.Vertex2f(float32(x+1), float32(y+10))
+		gl.Vertex2f(float32(x+7), float32(y+10))
+		gl.End()
+	case 'J':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+6))
+		gl.End()
+	case 'K':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+	case 'M':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+4), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+	case 'P':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.End()
+	case 'Q':
+		gl.Begin(gl.LINE_LOOP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+5), float32(y+7))
+		gl.Vertex2f(float32(x+9), float32(y+11))
+		gl.End()
+	case 'U':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.End()
+	case 'W':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+2), float32(y+10))
+		gl.Vertex2f(float32(x+4), float32(y+5))
+		gl.Vertex2f(float32(x+6), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.End()
+	case 'Y':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+4), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+4), float32(y+5))
+		gl.Vertex2f(float32(x+4), float32(y+5))
+		gl.Vertex2f(float32(x+4), float32(y+10))
+		gl.End()
+	case 'Z':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+	case '0':
+		gl.Begin(gl.LINE_LOOP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.End()
+	case '1':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+4), float32(y))
+		gl.Vertex2f(float32(x+4), float32(y+10))
+		gl.End()
+	case '2':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+	case '3':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.End()
+	case '4':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+	case '5':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.End()
+	case '6':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.End()
+	case '7':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.End()
+	case '8':
+		gl.Begin(gl.LINE_LOOP)
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.End()
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.End()
+	case '9':
+		gl.Begin(gl.LINE_STRIP)
+		gl.Vertex2f(float32(x), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y+10))
+		gl.Vertex2f(float32(x+8), float32(y))
+		gl.Vertex2f(float32(x), float32(y))
+		gl.Vertex2f(float32(x), float32(y+5))
+		gl.Vertex2f(float32(x+8), float32(y+5))
+		gl.End()
+	case '-':
+		gl.Begin(gl.LINES)
+		gl.Vertex2f(float32(x+1), float32(y+5))
+		gl.Vertex2f(float32(x+7), float32(y+5))
+		gl.End()
 	}
 }
 